@@ -36,6 +36,13 @@ var tablesCmd = &cobra.Command{
 	RunE:  tablesRun,
 }
 
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "Print the clauses supported by the dialect as JSON",
+	Args:  cobra.NoArgs,
+	RunE:  capabilitiesRun,
+}
+
 // currdir is the current directory of where the command was run from.
 var currdir string = func() string {
 	log.SetFlags(log.Lshortfile)
@@ -58,6 +65,8 @@ var (
 )
 
 func init() {
+	sqgenCmd.AddCommand(tablesCmd, capabilitiesCmd)
+
 	tablesDatabase = tablesCmd.Flags().String("database", "", "(required) Database URL")
 	tablesDirectory = tablesCmd.Flags().
 		String("directory", filepath.Join(currdir, "tables"), "(optional) Directory to place the generated file. Can be absolute or relative filepath")
@@ -157,6 +166,12 @@ func getWriter(dryrun, overwrite bool, directory, file string) (*os.File, error)
 	return os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 }
 
+// capabilitiesRun is the main function to be run with `sqgen-mysql capabilities`
+func capabilitiesRun(cmd *cobra.Command, args []string) error {
+	capabilities := mysql.Capabilities()
+	return capabilities.WriteJSON(os.Stdout)
+}
+
 func openAndPing(database string) (*sql.DB, error) {
 	db, err := sql.Open("mysql", database)
 
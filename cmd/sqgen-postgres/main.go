@@ -42,6 +42,13 @@ var functionsCmd = &cobra.Command{
 	RunE:  functionsRun,
 }
 
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "Print the clauses supported by the dialect as JSON",
+	Args:  cobra.NoArgs,
+	RunE:  capabilitiesRun,
+}
+
 // currdir is the current directory of where the command was run from.
 var currdir string = func() string {
 	log.SetFlags(log.Lshortfile)
@@ -61,6 +68,8 @@ var (
 	tablesPkg       *string
 	tablesSchemas   *[]string
 	tablesExclude   *[]string
+	tablesChildren  *bool
+	tablesTenant    *string
 
 	functionsDatabase  *string
 	functionsDirectory *string
@@ -73,7 +82,7 @@ var (
 )
 
 func init() {
-	sqgenCmd.AddCommand(tablesCmd, functionsCmd)
+	sqgenCmd.AddCommand(tablesCmd, functionsCmd, capabilitiesCmd)
 
 	// initialize tables flags
 
@@ -92,6 +101,10 @@ func init() {
 		StringSlice("schemas", []string{"public"}, "(optional) A comma separated list of database schemas that you want to generate tables for. Please don't include any spaces")
 	tablesExclude = tablesCmd.Flags().
 		StringSlice("exclude", nil, "(optional) A comma separated list of case-insensitive table names that you wish to exclude from table generation. Please don't include any spaces")
+	tablesChildren = tablesCmd.Flags().
+		Bool("annotate-children", false, "(optional) Annotate the tables that have child tables (inheritance or partitions) in their doc comments")
+	tablesTenant = tablesCmd.Flags().
+		String("tenant-template", "", "(optional) Generate the tables from this template schema for schema-per-tenant databases. The generated constructors resolve each tenant's schema at runtime. Cannot be used together with -schemas")
 	// required flag
 	err := cobra.MarkFlagRequired(tablesCmd.LocalFlags(), "database")
 
@@ -126,6 +139,16 @@ func init() {
 
 // tablesRun is the main function to be run with `sqgen-postgres tables`
 func tablesRun(cmd *cobra.Command, args []string) error {
+	schemas := *tablesSchemas
+
+	if *tablesTenant != "" {
+		if cmd.Flags().Changed("schemas") {
+			return fmt.Errorf("--tenant-template and --schemas cannot be used together")
+		}
+
+		schemas = nil
+	}
+
 	db, err := openAndPing(*tablesDatabase)
 
 	if err != nil {
@@ -134,11 +157,13 @@ func tablesRun(cmd *cobra.Command, args []string) error {
 
 	// dereference to get flag values
 	config := postgres.Config{
-		DB:      db,
-		Package: *tablesPkg,
-		Schemas: *tablesSchemas,
-		Exclude: *tablesExclude,
-		Logger:  log.New(os.Stderr, "", log.Ltime),
+		DB:               db,
+		Package:          *tablesPkg,
+		Schemas:          schemas,
+		Exclude:          *tablesExclude,
+		Logger:           log.New(os.Stderr, "", log.Ltime),
+		AnnotateChildren: *tablesChildren,
+		TenantTemplate:   *tablesTenant,
 	}
 
 	writer, err := getWriter(*tablesDryrun, *tablesOverwrite, *tablesDirectory, *tablesFile)
@@ -203,6 +228,12 @@ func functionsRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// capabilitiesRun is the main function to be run with `sqgen-postgres capabilities`
+func capabilitiesRun(cmd *cobra.Command, args []string) error {
+	capabilities := postgres.Capabilities()
+	return capabilities.WriteJSON(os.Stdout)
+}
+
 func openAndPing(database string) (*sql.DB, error) {
 	db, err := sql.Open("postgres", database)
 
@@ -0,0 +1,64 @@
+package sq
+
+import "fmt"
+
+// UnsupportedClauses lists the clauses that the query builders can build but
+// MySQL does not support, keyed by query type. Validation rejects a query that
+// uses one of them with an UnsupportedClauseError instead of leaving it to a
+// syntax error from the database, and the capabilities matrix of sqgen-mysql
+// marks them as unsupported.
+var UnsupportedClauses = map[string][]string{
+	"SELECT": {"FULL JOIN"},
+	"UPDATE": {"FULL JOIN"},
+	"DELETE": {"FULL JOIN"},
+}
+
+// UnsupportedClauseError is returned by validation for a query that uses one
+// of the UnsupportedClauses.
+type UnsupportedClauseError struct {
+	QueryType string
+	Clause    string
+}
+
+// Error implements the error interface.
+func (e UnsupportedClauseError) Error() string {
+	return fmt.Sprintf("sq: MySQL does not support %s in %s queries", e.Clause, e.QueryType)
+}
+
+// checkClauses returns an UnsupportedClauseError if the query uses one of the
+// UnsupportedClauses.
+func checkClauses(q Query) error {
+	queryType, clauses := usedClauses(q)
+	for _, unsupported := range UnsupportedClauses[queryType] {
+		for _, clause := range clauses {
+			if clause == unsupported {
+				return UnsupportedClauseError{QueryType: queryType, Clause: clause}
+			}
+		}
+	}
+	return nil
+}
+
+// usedClauses returns the query type of the query and the clauses that it
+// uses which the database may not support.
+func usedClauses(q Query) (queryType string, clauses []string) {
+	var joinTables JoinTables
+	switch q := q.(type) {
+	case SelectQuery:
+		queryType, joinTables = "SELECT", q.JoinTables
+	case InsertQuery:
+		queryType = "INSERT"
+	case UpdateQuery:
+		queryType, joinTables = "UPDATE", q.JoinTables
+	case DeleteQuery:
+		queryType, joinTables = "DELETE", q.JoinTables
+	}
+	for _, joinTable := range joinTables {
+		joinType := joinTable.JoinType
+		if joinType == "" {
+			joinType = JoinTypeInner
+		}
+		clauses = append(clauses, string(joinType))
+	}
+	return queryType, clauses
+}
@@ -0,0 +1,39 @@
+package sq
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// TestUnsupportedClauses checks that validation rejects every one of the
+// UnsupportedClauses, so that the list cannot name a clause that validation
+// does not detect.
+func TestUnsupportedClauses(t *testing.T) {
+	u, s := USERS(), SESSIONS()
+	samples := map[string]Query{
+		"SELECT FULL JOIN": From(u).FullJoin(s, s.USER_ID.Eq(u.USER_ID)).Select(u.EMAIL),
+		"UPDATE FULL JOIN": Update(u).FullJoin(s, s.USER_ID.Eq(u.USER_ID)).Set(u.EMAIL.SetString("a")),
+		"DELETE FULL JOIN": DeleteFrom(u).Using(u).FullJoin(s, s.USER_ID.Eq(u.USER_ID)),
+	}
+	for queryType, clauses := range UnsupportedClauses {
+		for _, clause := range clauses {
+			queryType, clause := queryType, clause
+			t.Run(queryType+" "+clause, func(t *testing.T) {
+				is := is.New(t)
+				q, ok := samples[queryType+" "+clause]
+				is.True(ok) // no sample query uses the clause
+				is.Equal(UnsupportedClauseError{QueryType: queryType, Clause: clause}, checkClauses(q))
+			})
+		}
+	}
+	is := is.New(t)
+	r := NewRegistry()
+	r.Register("full join", samples["SELECT FULL JOIN"])
+	db := prepareDB{missingTable: "devlab.nonexistent", queries: &[]string{}}
+	err := r.ValidateAll(db)
+	is.True(err != nil)
+	is.Equal(UnsupportedClauseError{QueryType: "SELECT", Clause: "FULL JOIN"}, err.(ValidationErrors)[0].Err)
+	is.Equal(0, len(*db.queries)) // never prepared
+	is.NoErr(checkClauses(From(u).Join(s, s.USER_ID.Eq(u.USER_ID)).LeftJoin(s, s.USER_ID.Eq(u.USER_ID)).Select(u.EMAIL)))
+}
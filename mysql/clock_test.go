@@ -0,0 +1,37 @@
+package sq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestBaseQuery_Now(t *testing.T) {
+	type TT struct {
+		description string
+		now         TimeField
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	first := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []TT{
+		{"package Now", Now(), "UPDATE devlab.users SET users.password = ?, users.updated_at = NOW()", []interface{}{"hunter2"}},
+		{"BaseQuery without Clock", BaseQuery{}.Now(), "UPDATE devlab.users SET users.password = ?, users.updated_at = NOW()", []interface{}{"hunter2"}},
+		{"FrozenClock", WithClock(FrozenClock(first)).Now(), "UPDATE devlab.users SET users.password = ?, users.updated_at = ?", []interface{}{"hunter2", first}},
+		{"another FrozenClock", WithDB(nil).WithClock(FrozenClock(second)).Now(), "UPDATE devlab.users SET users.password = ?, users.updated_at = ?", []interface{}{"hunter2", second}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			u := USERS()
+			q := Update(u).Set(u.PASSWORD.SetString("hunter2"), FieldAssignment{Field: NewTimeField("updated_at", u.TableInfo), Value: tt.now})
+			gotQuery, gotArgs := q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
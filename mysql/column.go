@@ -1,6 +1,9 @@
 package sq
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type colmode int
 
@@ -9,16 +12,39 @@ const (
 	colmodeUpdate
 )
 
+// ColumnFill determines what is inserted for an insert column that a row in
+// Valuesx did not set.
+type ColumnFill int
+
+// ColumnFills
+const (
+	// FillNone requires every row to set every insert column.
+	FillNone ColumnFill = iota
+	// FillNull inserts NULL for the missing columns.
+	FillNull
+	// FillDefault inserts DEFAULT for the missing columns.
+	FillDefault
+)
+
+func (fill ColumnFill) value() interface{} {
+	if fill == FillDefault {
+		return FieldLiteral("DEFAULT")
+	}
+	return nil
+}
+
 // Column keeps track of what the values mapped to what Field in an InsertQuery/SelectQuery.
 type Column struct {
 	// mode determines if INSERT or UPDATE
 	mode colmode
 	// INSERT
-	rowStart      bool
-	rowEnd        bool
-	firstField    string
+	fill          ColumnFill
+	explicitRows  bool
+	rowOpen       bool
 	insertColumns Fields
+	columnIndex   map[string]int
 	rowValues     RowValues
+	rowSet        []bool
 	// UPDATE
 	assignments Assignments
 }
@@ -39,26 +65,77 @@ func (col *Column) Set(field Field, value interface{}) {
 		fallthrough
 	default:
 		name := field.GetName()
-		if !col.rowStart {
-			col.rowStart = true
-			col.firstField = name
-			col.insertColumns = append(col.insertColumns, field)
-			col.rowValues = append(col.rowValues, RowValue{value})
-			return
+		index, ok := col.columnIndex[name]
+		if ok && col.rowOpen && col.rowSet[index] {
+			// The column was already set in the current RowValue. If every
+			// row sets every column, this can only mean that a new row has
+			// begun. Otherwise the rows must be separated with NextRow,
+			// because a row that skips a column cannot be told apart from
+			// the rows around it.
+			if col.explicitRows || col.fill != FillNone {
+				panic(fmt.Errorf("column %s was set twice in row %d, rows that skip columns must be separated with NextRow", name, len(col.rowValues)))
+			}
+			col.endRow()
 		}
-		switch name {
-		case col.firstField: // Start a new RowValue
-			if !col.rowEnd {
-				col.rowEnd = true
+		if !ok {
+			if col.fill == FillNone && (len(col.rowValues) > 1 || (len(col.rowValues) == 1 && !col.rowOpen)) {
+				panic(fmt.Errorf("column %s was not set in the first row", name))
 			}
-			col.rowValues = append(col.rowValues, RowValue{value})
-		default: // Append to last RowValue
-			if !col.rowEnd {
-				col.insertColumns = append(col.insertColumns, field)
+			// Add the column and fill it in for the previous RowValues
+			if col.columnIndex == nil {
+				col.columnIndex = make(map[string]int)
 			}
-			last := len(col.rowValues) - 1
-			col.rowValues[last] = append(col.rowValues[last], value)
+			index = len(col.insertColumns)
+			col.columnIndex[name] = index
+			col.insertColumns = append(col.insertColumns, field)
+			for i := range col.rowValues {
+				col.rowValues[i] = append(col.rowValues[i], col.fill.value())
+			}
+			col.rowSet = append(col.rowSet, false)
+		}
+		if !col.rowOpen { // Start a new RowValue
+			col.rowOpen = true
+			col.rowValues = append(col.rowValues, make(RowValue, len(col.insertColumns)))
+			for i := range col.rowSet {
+				col.rowSet[i] = false
+			}
+		}
+		// Values are placed according to the column order of the first
+		// RowValue, regardless of the order in which they were set
+		col.rowValues[len(col.rowValues)-1][index] = value
+		col.rowSet[index] = true
+	}
+}
+
+// NextRow ends the current row of values, so that the next value that is set
+// starts a new row. Rows in Valuesx must be separated with NextRow when
+// FillMissing is used, and may optionally be separated with it otherwise.
+// Calling NextRow before a row has any values does nothing.
+func (col *Column) NextRow() {
+	if col.mode != colmodeInsert {
+		return
+	}
+	col.explicitRows = true
+	col.endRow()
+}
+
+// endRow fills in the insert columns that the current RowValue did not set,
+// or panics if the Column has no ColumnFill, and then ends the RowValue.
+func (col *Column) endRow() {
+	if !col.rowOpen {
+		return
+	}
+	col.rowOpen = false
+	last := len(col.rowValues) - 1
+	for i, set := range col.rowSet {
+		if set {
+			continue
+		}
+		if col.fill != FillNone {
+			col.rowValues[last][i] = col.fill.value()
+			continue
 		}
+		panic(fmt.Errorf("column %s in row %d was not set", col.insertColumns[i].GetName(), len(col.rowValues)))
 	}
 }
 
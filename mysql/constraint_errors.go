@@ -0,0 +1,69 @@
+package sq
+
+import "regexp"
+
+// ConstraintErrors maps constraint names to the errors that should be returned
+// in place of any database error that violates them e.g.
+// ConstraintErrors{"users_email_key": ErrEmailTaken}.
+type ConstraintErrors map[string]error
+
+// ConstraintErrorTable is implemented by tables that map their constraint names
+// to errors. Since generated tables should not be edited, the method can be
+// added to the table type in a separate file of the same package. The table's
+// ConstraintErrors are consulted after the query's own ConstraintErrors.
+type ConstraintErrorTable interface {
+	BaseTable
+	ConstraintErrors() ConstraintErrors
+}
+
+var constraintMessageRegexps = []*regexp.Regexp{
+	// Error 1062: Duplicate entry 'bob@email.com' for key 'users.users_email_key'
+	regexp.MustCompile(`for key '(?:[^'.]+\.)?([^']+)'`),
+	// Error 1452: Cannot add or update a child row: a foreign key constraint
+	// fails (`db`.`child`, CONSTRAINT `child_parent_id_fkey` FOREIGN KEY ...
+	regexp.MustCompile("CONSTRAINT `([^`]+)`"),
+	// Error 3819: Check constraint 'users_age_check' is violated.
+	regexp.MustCompile(`[Cc]onstraint '([^']+)'`),
+}
+
+// constraintName returns the name of the constraint violated by err, or an
+// empty string if it cannot be determined. The MySQL driver does not expose
+// the constraint name so it is parsed from the error message.
+func constraintName(err error) string {
+	for _, re := range constraintMessageRegexps {
+		if match := re.FindStringSubmatch(err.Error()); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// mapConstraintError returns the error mapped to the constraint violated by
+// err, checking errs before the ConstraintErrors of the tables. If there is no
+// mapping for the constraint, err is returned as is.
+func mapConstraintError(err error, errs ConstraintErrors, tables ...BaseTable) error {
+	if err == nil {
+		return nil
+	}
+	var name string
+	lookup := func(errs ConstraintErrors) error {
+		if len(errs) == 0 {
+			return nil
+		}
+		if name == "" {
+			name = constraintName(err)
+		}
+		return errs[name]
+	}
+	if mapped := lookup(errs); mapped != nil {
+		return mapped
+	}
+	for _, table := range tables {
+		if table, ok := table.(ConstraintErrorTable); ok {
+			if mapped := lookup(table.ConstraintErrors()); mapped != nil {
+				return mapped
+			}
+		}
+	}
+	return err
+}
@@ -1,6 +1,9 @@
 package sq
 
 import (
+	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -11,6 +14,7 @@ const (
 	metadataName      = "𝑛𝑎𝑚𝑒"
 	metadataAlias     = "𝑎𝑙𝑖𝑎𝑠"
 	metadataColumns   = "𝑐𝑜𝑙𝑢𝑚𝑛𝑠"
+	metadataFields    = "𝑓𝑖𝑒𝑙𝑑𝑠"
 )
 
 // CTE represents an SQL CTE.
@@ -84,45 +88,83 @@ func appendCTEs(buf *strings.Builder, args *[]interface{}, CTEs []CTE, fromTable
 	buf.WriteString(" ")
 }
 
-// CTE converts a SelectQuery into a CTE.
-func (q SelectQuery) CTE(name string, columns ...string) CTE {
+// newCTE constructs a CTE named name around query q. If columns are
+// explicitly provided they become the CTE's columns, otherwise the columns are
+// derived from the fields that the query outputs.
+func newCTE(q Query, name string, columns []string, fields Fields) CTE {
 	cte := map[string]CustomField{
 		metadataQuery:   {Values: []interface{}{q}},
 		metadataName:    {Values: []interface{}{name}},
 		metadataAlias:   {Values: []interface{}{""}},
 		metadataColumns: {Values: []interface{}{columns}},
+		metadataFields:  {Values: []interface{}{map[string]Field{}}},
 	}
-	for _, field := range q.SelectFields {
-		column := getAliasOrName(field)
+	for _, column := range columns {
 		cte[column] = CustomField{Format: name + "." + column}
 	}
+	for i, field := range fields {
+		if len(columns) == 0 {
+			addCTEColumn(cte, name, cteColumnName(field), field)
+		} else if i < len(columns) {
+			addCTEColumn(cte, name, columns[i], field)
+		}
+	}
 	return cte
 }
 
-// CTE converts a VariadicQuery into a CTE.
-func (vq VariadicQuery) CTE(name string, columns ...string) CTE {
-	cte := map[string]CustomField{
-		metadataQuery:   {Values: []interface{}{vq}},
-		metadataName:    {Values: []interface{}{name}},
-		metadataAlias:   {Values: []interface{}{""}},
-		metadataColumns: {Values: []interface{}{columns}},
+// addCTEColumn adds the column that the field is output as to the CTE. The
+// name of the field itself (e.g. 'cte1.user_id' for a column that is output
+// as 'user_id', or the field at the same position as a column in an explicit
+// column list) is kept as an alias key for the column, so that the CTE can
+// still be indexed with it. An alias key never replaces a column. The field
+// is kept as the source of the column, which decides the type of field that
+// Get returns for it.
+func addCTEColumn(cte CTE, name string, column string, field Field) {
+	cte[column] = CustomField{Format: name + "." + column}
+	if sources := cte.sourceFields(); sources != nil {
+		sources[column] = field
 	}
-	if len(columns) > 0 {
-		for _, column := range columns {
-			cte[column] = CustomField{Format: name + "." + column}
+	for _, key := range []string{getAliasOrName(field), cteColumnName(field)} {
+		if _, ok := cte[key]; !ok {
+			cte[key] = CustomField{Format: name + "." + column}
 		}
-		return cte
 	}
+}
+
+// cteColumnName returns the name of the column that a field will be output as
+// in a CTE. An unaliased qualified column reference like 'cte.user_id' is
+// output by the database as 'user_id', which lets columns propagate through a
+// chain of CTEs without having to re-alias them at every step.
+func cteColumnName(field Field) string {
+	column := getAliasOrName(field)
+	if field.GetAlias() == "" && qualifiedColumnRegexp.MatchString(column) {
+		column = column[strings.LastIndex(column, ".")+1:]
+	}
+	return column
+}
+
+var qualifiedColumnRegexp = regexp.MustCompile(`^\w+(\.\w+)+$`)
+
+// CTE converts a SelectQuery into a CTE. The CTE is indexed by the names of
+// the columns that the database outputs: an unaliased qualified field such as
+// cte1.user_id is output as user_id, and with an explicit column list the
+// columns are named by the list. The names of the selected fields are kept as
+// aliases for their columns, so a CTE indexed with cte["cte1.user_id"] or by
+// the name of a field that a column list renamed still resolves to the right
+// column. AvailableColumns and the error from Get only list the column names.
+func (q SelectQuery) CTE(name string, columns ...string) CTE {
+	return newCTE(q, name, columns, q.SelectFields)
+}
+
+// CTE converts a VariadicQuery into a CTE.
+func (vq VariadicQuery) CTE(name string, columns ...string) CTE {
+	var fields Fields
 	if len(vq.Queries) > 0 {
-		switch q := vq.Queries[0].(type) {
-		case SelectQuery:
-			for _, field := range q.SelectFields {
-				column := getAliasOrName(field)
-				cte[column] = CustomField{Format: name + "." + column}
-			}
+		if q, ok := vq.Queries[0].(SelectQuery); ok {
+			fields = q.SelectFields
 		}
 	}
-	return cte
+	return newCTE(vq, name, columns, fields)
 }
 
 // As returns a new CTE with the alias i.e. 'CTE AS alias'.
@@ -132,17 +174,95 @@ func (cte CTE) As(alias string) CTE {
 		metadataName:    {Values: []interface{}{cte.GetName()}},
 		metadataAlias:   {Values: []interface{}{alias}},
 		metadataColumns: {Values: []interface{}{cte.GetColumns()}},
+		metadataFields:  cte[metadataFields],
 	}
-	for column := range cte {
-		switch column {
-		case metadataQuery, metadataName, metadataAlias, metadataColumns:
+	for key, field := range cte {
+		if isCTEMetadata(key) {
 			continue
 		}
-		newcte[column] = CustomField{Format: alias + "." + column}
+		column := field.Format[strings.Index(field.Format, ".")+1:]
+		newcte[key] = CustomField{Format: alias + "." + column}
 	}
 	return newcte
 }
 
+// Get returns the CTE column with the given name. The column is returned as a
+// field of the same type as the field that the CTE's query outputs it from
+// (e.g. a NumberField for a column selected from a NumberField), which can be
+// type asserted to use the methods of that type:
+//
+//	cte.Get("user_id").(NumberField).EqInt(1)
+//
+// A column without a typed source, such as one selected from a CustomField,
+// is returned as a CustomField. Unlike indexing the CTE directly, which
+// silently yields a blank field for a misspelled column, a column that does
+// not exist in the CTE results in an error when the query is built. The error
+// lists the columns that are available.
+func (cte CTE) Get(column string) Field {
+	field, ok := cte[column]
+	if !ok || isCTEMetadata(column) {
+		return CustomField{err: fmt.Errorf("CTE %s has no column %s (available columns: %s)",
+			cte.GetName(), column, strings.Join(cte.AvailableColumns(), ", "))}
+	}
+	// alias keys resolve to the column that they are an alias for
+	column = field.Format[strings.Index(field.Format, ".")+1:]
+	table := &TableInfo{Name: cte.GetName(), Alias: cte.GetAlias()}
+	switch source := cte.sourceFields()[column].(type) {
+	case BinaryField:
+		return NewBinaryField(column, table)
+	case BooleanField:
+		return NewBooleanField(column, table)
+	case JSONField:
+		return NewJSONField(column, table)
+	case NumberField:
+		return NewNumberField(column, table)
+	case StringField:
+		f := NewStringField(column, table)
+		f.enumValues = source.enumValues
+		return f
+	case TimeField:
+		return NewTimeField(column, table)
+	}
+	return field
+}
+
+// AvailableColumns returns the names of the columns that can be selected from
+// the CTE, in sorted order. Alias keys added for the names of the fields are
+// not included. Unlike GetColumns, which only returns the explicit column list
+// that the CTE was declared with, it includes the columns derived from the
+// fields that the CTE's query outputs.
+func (cte CTE) AvailableColumns() []string {
+	var columns []string
+	for column, field := range cte {
+		if isCTEMetadata(column) || !strings.HasSuffix(field.Format, "."+column) {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// sourceFields returns the fields that the CTE's columns are output from,
+// keyed by column name.
+func (cte CTE) sourceFields() map[string]Field {
+	field := cte[metadataFields]
+	if len(field.Values) > 0 {
+		if fields, ok := field.Values[0].(map[string]Field); ok {
+			return fields
+		}
+	}
+	return nil
+}
+
+func isCTEMetadata(column string) bool {
+	switch column {
+	case metadataQuery, metadataRecursive, metadataName, metadataAlias, metadataColumns, metadataFields:
+		return true
+	}
+	return false
+}
+
 // AppendSQL marshals the CTE into a buffer and args slice.
 func (cte CTE) AppendSQL(buf *strings.Builder, args *[]interface{}, params map[string]int) {
 	buf.WriteString(cte.GetName())
@@ -170,7 +290,8 @@ func (cte CTE) GetQuery() Query {
 	return nil
 }
 
-// GetColumns returns the CTE's columns.
+// GetColumns returns the CTE's explicit column list, if it was declared with
+// one. Use AvailableColumns for all the columns that can be selected from it.
 func (cte CTE) GetColumns() []string {
 	field := cte[metadataColumns]
 	if len(field.Values) > 0 {
@@ -209,6 +330,7 @@ func RecursiveCTE(name string, columns ...string) CTE {
 		metadataRecursive: {Values: []interface{}{true}},
 		metadataName:      {Values: []interface{}{name}},
 		metadataAlias:     {Values: []interface{}{""}},
+		metadataFields:    {Values: []interface{}{map[string]Field{}}},
 	}
 	if len(columns) > 0 {
 		cte[metadataColumns] = CustomField{Values: []interface{}{columns}}
@@ -242,8 +364,7 @@ func (cte *CTE) Initial(query Query) IntermediateCTE {
 	switch q := query.(type) {
 	case SelectQuery:
 		for _, field := range q.SelectFields {
-			column := getAliasOrName(field)
-			(*cte)[column] = CustomField{Format: name + "." + column}
+			addCTEColumn(*cte, name, cteColumnName(field), field)
 		}
 	}
 	return IntermediateCTE(*cte)
@@ -9,11 +9,16 @@ type CustomField struct {
 	Format string
 	Values []interface{}
 	IsDesc *bool
+	// err is set by Fieldf if the Format looks unsafe
+	err error
 }
 
 // AppendSQLExclude marshals the CustomField into a buffer and an args slice.
 // It propagates the excludedTableQualifiers down to its child elements.
 func (f CustomField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	if f.err != nil {
+		panic(f.err)
+	}
 	expandValues(buf, args, excludedTableQualifiers, f.Format, f.Values)
 	if f.IsDesc != nil {
 		if *f.IsDesc {
@@ -24,11 +29,15 @@ func (f CustomField) AppendSQLExclude(buf *strings.Builder, args *[]interface{},
 	}
 }
 
-// Fieldf creates a new CustomField.
+// Fieldf creates a new CustomField. Values must be passed in as ? arguments
+// rather than concatenated into the format: a format that contains a string
+// literal, a statement separator or a comment is rejected with an error when
+// the query is built. Use an UnsafeLiteral for trusted SQL that needs them.
 func Fieldf(format string, values ...interface{}) CustomField {
 	return CustomField{
 		Format: format,
 		Values: values,
+		err:    checkFormat(format),
 	}
 }
 
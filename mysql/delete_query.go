@@ -29,7 +29,10 @@ type DeleteQuery struct {
 	// LIMIT
 	LimitValue *int64
 	// DB
-	DB DB
+	DB               DB
+	PredicateGuard   *PredicateGuard
+	RetryPolicy      *RetryPolicy
+	ConstraintErrors ConstraintErrors
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -64,6 +67,7 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 				buf.WriteString("NULL")
 				continue
 			}
+			checkWritable(table, "DELETE FROM")
 			alias := table.GetAlias()
 			if alias != "" {
 				buf.WriteString(alias)
@@ -252,6 +256,27 @@ func (q DeleteQuery) Limit(limit int) DeleteQuery {
 	return q
 }
 
+// MapConstraintErrors sets the errors to be returned by the DeleteQuery in place
+// of database errors that violate the named constraints.
+func (q DeleteQuery) MapConstraintErrors(errs ConstraintErrors) DeleteQuery {
+	q.ConstraintErrors = errs
+	return q
+}
+
+// Guard sets the PredicateGuard that the DeleteQuery is checked (and possibly
+// amended) against when it is executed.
+func (q DeleteQuery) Guard(guard PredicateGuard) DeleteQuery {
+	q.PredicateGuard = &guard
+	return q
+}
+
+// Retry sets the RetryPolicy that is used to run the DeleteQuery again if it fails
+// with a transient error.
+func (q DeleteQuery) Retry(policy RetryPolicy) DeleteQuery {
+	q.RetryPolicy = &policy
+	return q
+}
+
 // Exec will execute the DeleteQuery with the given DB. It will only compute
 // the rowsAffected if the ErowsAffected Execflag is passed to it.
 func (q DeleteQuery) Exec(db DB, flag ExecFlag) (rowsAffected int64, err error) {
@@ -270,7 +295,17 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			default:
+				err = fmt.Errorf("%#v", r)
+			}
+			return
+		}
 		if q.Log == nil {
 			return
 		}
@@ -280,6 +315,7 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -292,6 +328,13 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 	}()
 	var res sql.Result
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return 0, err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
@@ -302,7 +345,7 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return rowsAffected, err
+		return rowsAffected, mapConstraintError(err, q.ConstraintErrors, q.FromTables...)
 	}
 	if res != nil && ErowsAffected&flag != 0 {
 		rowsAffected, err = res.RowsAffected()
@@ -0,0 +1,112 @@
+package sq
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestSelectQuery_FetchCSV(t *testing.T) {
+	is := is.New(t)
+	u := USERS()
+	dbErr := errors.New("database reached")
+	buf := &strings.Builder{}
+	err := From(u).Select(u.EMAIL).FetchCSV(errDB{dbErr}, buf, CSVOptions{})
+	is.True(err != nil) // no mapper
+	err = From(u).SelectRowx(func(row *Row) { row.String(u.EMAIL) }).FetchCSV(errDB{dbErr}, buf, CSVOptions{})
+	is.Equal(dbErr, err)
+	err = From(u).SelectRowx(func(row *Row) { row.String(u.EMAIL) }).FetchNDJSON(errDB{dbErr}, buf)
+	is.Equal(dbErr, err)
+	// Nothing is written if the query fails
+	is.Equal("", buf.String())
+}
+
+func TestSelectQuery_FetchCSV_Rows(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+	is := is.New(t)
+	db, err := sql.Open("txdb", "SelectQuery_FetchCSV_Rows")
+	is.NoErr(err)
+	defer db.Close()
+	u := USERS()
+	var rowCount int
+	q := WithDB(db).
+		From(u).
+		Where(u.USER_ID.LeInt(2)).
+		OrderBy(u.USER_ID).
+		Selectx(func(row *Row) {
+			row.Int(u.USER_ID)
+			row.String(u.DISPLAYNAME)
+			row.String(u.EMAIL.As("mail"))
+			row.NullString(u.PASSWORD)
+		}, func() {
+			rowCount++
+		})
+
+	// CSV, with a header named after the aliases
+	buf := &strings.Builder{}
+	err = q.FetchCSV(nil, buf, CSVOptions{Null: "NULL"})
+	is.NoErr(err)
+	is.Equal("user_id,displayname,mail,password\n"+
+		"1,Adviser01,adviser01@u.nus.edu,NULL\n"+
+		"2,Adviser02,adviser02@u.nus.edu,NULL\n", buf.String())
+	is.Equal(0, rowCount) // the accumulator is not called
+
+	// CSV without a header and with a different delimiter
+	buf.Reset()
+	err = q.FetchCSV(nil, buf, CSVOptions{Comma: ';', NoHeader: true})
+	is.NoErr(err)
+	is.Equal("1;Adviser01;adviser01@u.nus.edu;\n"+
+		"2;Adviser02;adviser02@u.nus.edu;\n", buf.String())
+
+	// NDJSON
+	buf.Reset()
+	err = q.FetchNDJSON(nil, buf)
+	is.NoErr(err)
+	is.Equal(`{"user_id":1,"displayname":"Adviser01","mail":"adviser01@u.nus.edu","password":null}`+"\n"+
+		`{"user_id":2,"displayname":"Adviser02","mail":"adviser02@u.nus.edu","password":null}`+"\n", buf.String())
+
+	// The header is written even if there are no rows
+	buf.Reset()
+	err = q.Where(u.USER_ID.EqInt(-999999)).FetchCSV(nil, buf, CSVOptions{})
+	is.NoErr(err)
+	is.Equal("user_id,displayname,mail,password\n", buf.String())
+}
+
+func TestExportValue(t *testing.T) {
+	type TT struct {
+		description string
+		dest        interface{}
+		wantCSV     string
+		wantJSON    string
+	}
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	str := "it's"
+	tests := []TT{
+		{"null", &sql.NullString{}, "NULL", "null"},
+		{"string", &sql.NullString{String: "a,b", Valid: true}, "a,b", `"a,b"`},
+		{"int", &sql.NullInt64{Int64: 42, Valid: true}, "42", "42"},
+		{"float", &sql.NullFloat64{Float64: 1.5, Valid: true}, "1.5", "1.5"},
+		{"bool", &sql.NullBool{Bool: true, Valid: true}, "true", "true"},
+		{"time", &sql.NullTime{Time: createdAt, Valid: true}, "2020-01-02T03:04:05Z", `"2020-01-02T03:04:05Z"`},
+		{"pointer", &str, "it's", `"it's"`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			value, err := exportValue(tt.dest)
+			is.NoErr(err)
+			is.Equal(tt.wantCSV, csvValue(value, "NULL"))
+			b, err := json.Marshal(value)
+			is.NoErr(err)
+			is.Equal(tt.wantJSON, string(b))
+		})
+	}
+}
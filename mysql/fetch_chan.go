@@ -0,0 +1,69 @@
+package sq
+
+import "context"
+
+// FetchChan runs the SelectQuery with the given DB in a new goroutine and
+// sends the value that the mapper function returns for each row on the
+// returned rows channel as it is fetched. The mapper function is used in place
+// of the SelectQuery's own mapper and accumulator functions, e.g.
+//
+//	rows, errs, stop := From(u).FetchChan(ctx, db, 10, func(row *Row) interface{} {
+//	    return User{UserID: row.Int(u.USER_ID), Email: row.String(u.EMAIL)}
+//	})
+//	defer stop()
+//	for v := range rows {
+//	    user := v.(User)
+//	}
+//	if err := <-errs; err != nil {
+//	    return err
+//	}
+//
+// At most buffer rows are held in the rows channel: the query stops reading
+// rows from the database until the consumer catches up. The rows channel is
+// closed once the query is done, after which the error channel receives the
+// error (if any) and is closed.
+//
+// stop cancels the query and waits for the goroutine to exit. It must be
+// called once the consumer is done with the rows (usually by deferring it), so
+// that the goroutine does not stay blocked on a consumer that stopped reading
+// early. Cancelling ctx also stops the query, and sends ctx.Err() on the error
+// channel.
+func (q SelectQuery) FetchChan(ctx context.Context, db DB, buffer int, mapper func(*Row) interface{}) (rows <-chan interface{}, errs <-chan error, stop func()) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	rowsChan := make(chan interface{}, buffer)
+	errChan := make(chan error, 1)
+	done := make(chan struct{})
+	stop = func() {
+		cancel()
+		<-done
+	}
+	q.RowMapper, q.Accumulator = nil, nil
+	if mapper != nil {
+		var value interface{}
+		q.RowMapper = func(row *Row) {
+			value = mapper(row)
+		}
+		q.Accumulator = func() {
+			select {
+			case rowsChan <- value:
+			case <-ctx.Done():
+				panic(ctx.Err())
+			}
+		}
+	}
+	q.logSkip += 1
+	go func() {
+		defer close(done)
+		defer cancel()
+		defer close(errChan)
+		err := q.FetchContext(ctx, db)
+		close(rowsChan)
+		if err != nil {
+			errChan <- err
+		}
+	}()
+	return rowsChan, errChan, stop
+}
@@ -33,6 +33,17 @@ func TestFieldLiteral_AppendSQLExclude(t *testing.T) {
 	}
 }
 
+func TestUnsafeLiteral(t *testing.T) {
+	is := is.New(t)
+	buf := &strings.Builder{}
+	var args []interface{}
+	var p Predicate = UnsafeLiteral("1 = 1; SELECT 1")
+	p.Not().AppendSQLExclude(buf, &args, nil, nil)
+	is.Equal("NOT 1 = 1; SELECT 1", buf.String())
+	is.Equal(0, len(args))
+	is.Equal("1 = 1; SELECT 1", p.GetName())
+}
+
 func TestFields_AppendSQLExclude(t *testing.T) {
 	type TT struct {
 		description string
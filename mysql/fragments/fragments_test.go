@@ -0,0 +1,122 @@
+package fragments
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	sq "github.com/bokwoon95/go-structured-query/mysql"
+	"github.com/matryer/is"
+)
+
+func TestNew(t *testing.T) {
+	type TT struct {
+		description string
+		format      string
+		wantFormat  string
+		wantSlots   []string
+		wantErr     bool
+	}
+	tests := []TT{
+		{"no slots", "COUNT(*)", "COUNT(*)", nil, false},
+		{"slots", "{field} BETWEEN {start} AND {end}", "? BETWEEN ? AND ?", []string{"field", "start", "end"}, false},
+		{"repeated slot", "{a} + {b} * {a}", "? + ? * ?", []string{"a", "b", "a"}, false},
+		{"escaped brace", "{field} @> '{{1,2}'", "? @> '{1,2}'", []string{"field"}, false},
+		{"not a slot", `{field} = '{"a b": 1}'`, `? = '{"a b": 1}'`, []string{"field"}, false},
+		{"question mark", "{field} = ?", "", nil, true},
+		{"quoted question mark", "{field} = 'what?'", "? = 'what?'", []string{"field", ""}, false},
+		{"escaped question mark", "{field} ?? {key}", "", nil, true},
+		{"question mark in backslash escaped string", `{field} = 'it\'s?'`, `? = 'it\'s?'`, []string{"field", ""}, false},
+		{"question mark after backslash in identifier", "`a\\` = ?", "", nil, true},
+		{"escaped closing brace", "{field} = '{{1,{{2}}}}'", "? = '{1,{2}}'", []string{"field"}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			tmpl, err := New(tt.format)
+			if tt.wantErr {
+				is.True(err != nil)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(tt.wantFormat, tmpl.format)
+			is.Equal(tt.wantSlots, tmpl.slots)
+		})
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	u := struct {
+		*sq.TableInfo
+		USER_ID    sq.NumberField
+		CREATED_AT sq.TimeField
+	}{TableInfo: &sq.TableInfo{Schema: "devlab", Name: "users", Alias: "u"}}
+	u.USER_ID = sq.NewNumberField("user_id", u.TableInfo)
+	u.CREATED_AT = sq.NewTimeField("created_at", u.TableInfo)
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	between := MustNew("{field} BETWEEN {start} AND {end}")
+	coalesce := MustNew("COALESCE({value}, {fallback})")
+
+	t.Run("Predicate", func(t *testing.T) {
+		is := is.New(t)
+		query, args := sq.From(u).
+			Where(between.Predicate(Values{"field": u.CREATED_AT, "start": start, "end": end})).
+			Select(u.USER_ID).
+			ToSQL()
+		is.Equal("SELECT u.user_id FROM devlab.users AS u WHERE u.created_at BETWEEN ? AND ?", query)
+		is.Equal([]interface{}{start, end}, args)
+	})
+	t.Run("Not Predicate", func(t *testing.T) {
+		is := is.New(t)
+		query, args := sq.From(u).
+			Where(between.Predicate(Values{"field": u.USER_ID, "start": 1, "end": 10}).Not()).
+			Select(u.USER_ID).
+			ToSQL()
+		is.Equal("SELECT u.user_id FROM devlab.users AS u WHERE NOT u.user_id BETWEEN ? AND ?", query)
+		is.Equal([]interface{}{1, 10}, args)
+	})
+	t.Run("nested Field", func(t *testing.T) {
+		is := is.New(t)
+		created := coalesce.Field(Values{"value": u.CREATED_AT, "fallback": start}).As("created")
+		query, args := sq.From(u).
+			Where(between.Predicate(Values{
+				"field": coalesce.Field(Values{"value": u.CREATED_AT, "fallback": start}),
+				"start": start,
+				"end":   end,
+			})).
+			Select(created).
+			ToSQL()
+		is.Equal("SELECT COALESCE(u.created_at, ?) AS created FROM devlab.users AS u"+
+			" WHERE COALESCE(u.created_at, ?) BETWEEN ? AND ?", query)
+		is.Equal([]interface{}{start, start, start, end}, args)
+	})
+	t.Run("subquery", func(t *testing.T) {
+		is := is.New(t)
+		in := MustNew("{field} IN ({query})")
+		query, _ := sq.From(u).
+			Where(in.Predicate(Values{"field": u.USER_ID, "query": sq.Select(u.USER_ID).From(u)})).
+			Select(u.USER_ID).
+			ToSQL()
+		is.Equal("SELECT u.user_id FROM devlab.users AS u WHERE u.user_id IN (SELECT u.user_id FROM devlab.users AS u)", query)
+	})
+	t.Run("literal question marks", func(t *testing.T) {
+		is := is.New(t)
+		what := MustNew("{field} <> 'what?'")
+		is.Equal([]string{"field"}, what.Slots())
+		query, args := sq.From(u).
+			Where(what.Predicate(Values{"field": u.USER_ID})).
+			Select(u.USER_ID).
+			ToSQL()
+		is.Equal("SELECT u.user_id FROM devlab.users AS u WHERE u.user_id <> 'what?'", query)
+		is.Equal(0, len(args))
+	})
+	t.Run("missing slot", func(t *testing.T) {
+		is := is.New(t)
+		_, args := sq.Update(u).
+			Set(u.CREATED_AT.Set(coalesce.Field(Values{"value": u.CREATED_AT}))).
+			ToSQL()
+		is.Equal([]interface{}{errors.New("fragments: no value for slot {fallback}")}, args)
+	})
+}
@@ -0,0 +1,96 @@
+package sq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func predicateStrings(predicates []Predicate) []string {
+	var strs []string
+	for _, predicate := range predicates {
+		buf := &strings.Builder{}
+		var args []interface{}
+		predicate.AppendSQLExclude(buf, &args, nil, nil)
+		strs = append(strs, buf.String())
+	}
+	return strs
+}
+
+func TestIntrospection(t *testing.T) {
+	type TT struct {
+		description string
+		q           interface {
+			Tables() []Table
+			Predicates() []Predicate
+		}
+		wantTables     []Table
+		wantPredicates []string
+	}
+	u, s := USERS().As("u"), SESSIONS().As("s")
+	tests := []TT{
+		{
+			"Select",
+			Select(u.USER_ID).From(u).Join(s, s.USER_ID.Eq(u.USER_ID)).
+				Where(u.USER_ID.EqInt(1), Or(u.EMAIL.IsNull(), u.DISPLAYNAME.IsNull()), And(s.HASH.IsNotNull())),
+			[]Table{u, s},
+			[]string{"u.user_id = ?", "(u.email IS NULL OR u.displayname IS NULL)", "s.hash IS NOT NULL"},
+		},
+		{
+			"Select negated AND",
+			Select(u.USER_ID).From(u).Where(Not(And(u.USER_ID.EqInt(1), u.EMAIL.IsNull()))),
+			[]Table{u},
+			[]string{"NOT (u.user_id = ? AND u.email IS NULL)"},
+		},
+		{
+			"Insert select",
+			InsertInto(u).Columns(u.USER_ID).Select(Select(s.USER_ID).From(s).Where(s.HASH.IsNotNull())),
+			[]Table{u, s},
+			[]string{"s.hash IS NOT NULL"},
+		},
+		{
+			"Update",
+			Update(u).Join(s, s.USER_ID.Eq(u.USER_ID)).Set(u.EMAIL.SetString("x")).Where(u.EMAIL.IsNull()),
+			[]Table{u, s},
+			[]string{"u.email IS NULL"},
+		},
+		{
+			"Delete",
+			DeleteFrom(u).Using(u).Join(s, s.USER_ID.Eq(u.USER_ID)).Where(s.USER_ID.Eq(u.USER_ID), u.EMAIL.IsNull()),
+			[]Table{u, u, s},
+			[]string{"s.user_id = u.user_id", "u.email IS NULL"},
+		},
+		{
+			"Delete multiple tables",
+			DeleteFrom(u, s).Using(u).Where(s.USER_ID.Eq(u.USER_ID), u.EMAIL.IsNull()),
+			[]Table{u, s, u},
+			[]string{"s.user_id = u.user_id", "u.email IS NULL"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			is.Equal(tt.wantTables, tt.q.Tables())
+			is.Equal(tt.wantPredicates, predicateStrings(tt.q.Predicates()))
+		})
+	}
+}
+
+func TestSelectedFields(t *testing.T) {
+	is := is.New(t)
+	u := USERS()
+	q := Select(u.USER_ID, u.EMAIL).From(u)
+	fields := q.SelectedFields()
+	is.Equal(Fields{u.USER_ID, u.EMAIL}, fields)
+	fields[0] = u.DISPLAYNAME // modifying the result does not modify the query
+	is.Equal(Fields{u.USER_ID, u.EMAIL}, q.SelectFields)
+	// The fields of a mapper are known before the query is fetched
+	q = From(u).Selectx(func(row *Row) {
+		row.Int(u.USER_ID)
+		row.String(u.EMAIL)
+	}, func() {})
+	is.Equal(Fields{u.USER_ID, u.EMAIL}, q.SelectedFields())
+}
@@ -0,0 +1,62 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// prepareDB is a Preparer that fails to prepare the queries that mention a
+// missing table.
+type prepareDB struct {
+	missingTable string
+	queries      *[]string
+}
+
+func (db prepareDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	*db.queries = append(*db.queries, query)
+	if strings.Contains(query, db.missingTable) {
+		return nil, errors.New("Error 1146: Table '" + db.missingTable + "' doesn't exist")
+	}
+	return nil, nil
+}
+
+func TestRegistry(t *testing.T) {
+	is := is.New(t)
+	u, a := USERS(), APPLICATIONS()
+	r := NewRegistry()
+	r.Register("user email", Select(u.EMAIL).From(u).Where(u.USER_ID.EqInt(1)))
+	r.Register("applications", Select(a.APPLICATION_ID).From(a))
+	r.Register("user ids", From(u).Selectx(func(row *Row) { row.Int(u.USER_ID) }, func() {}))
+	r.Register("bad insert", InsertInto(&TableInfo{Schema: "public", Name: "user_stats", ReadOnly: true}).Columns(FieldLiteral("a")).Values(1))
+	is.Equal([]string{"user email", "applications", "user ids", "bad insert"}, r.Names())
+	_, ok := r.Query("applications")
+	is.True(ok)
+	func() {
+		defer func() { is.True(recover() != nil) }()
+		r.Register("applications", Select(a.APPLICATION_ID).From(a))
+	}()
+
+	db := prepareDB{missingTable: "devlab.applications", queries: &[]string{}}
+	err := r.ValidateAll(db)
+	var errs ValidationErrors
+	is.True(errors.As(err, &errs))
+	is.Equal(2, len(errs))
+	is.Equal("applications", errs[0].Name)
+	is.Equal("SELECT applications.application_id FROM devlab.applications", errs[0].Query)
+	is.Equal(`sq: query applications is invalid: Error 1146: Table 'devlab.applications' doesn't exist`, errs[0].Error())
+	is.Equal("bad insert", errs[1].Name) // fails to build, so it is never prepared
+	is.Equal(ReadOnlyError{View: "user_stats", Statement: "INSERT INTO"}, errs[1].Err)
+	is.Equal([]string{
+		"SELECT users.email FROM devlab.users WHERE users.user_id = ?",
+		"SELECT applications.application_id FROM devlab.applications",
+		"SELECT users.user_id FROM devlab.users",
+	}, *db.queries)
+
+	is.NoErr(NewRegistry().ValidateAll(db))
+	is.True(r.ValidateAll(nil) != nil)
+}
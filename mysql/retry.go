@@ -0,0 +1,194 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"time"
+)
+
+// RetryPolicy decides if and when a query that failed with a transient error
+// (such as a dropped connection or a failover) is run again. Only the call
+// that sends the query to the database is retried: once rows have started
+// coming back, errors are returned as is.
+//
+// Do not use a RetryPolicy with a DB that is an *sql.Tx, since a transaction
+// does not survive the errors that are worth retrying.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times the query is run, including the
+	// first attempt. Values less than 2 mean that the query is not retried.
+	MaxAttempts int
+	// Backoff returns how long to wait before the given retry (starting from
+	// 1). A nil Backoff retries immediately.
+	Backoff func(retry int) time.Duration
+	// Retryable reports whether the error is transient. A nil Retryable uses
+	// IsBadConnError, which only retries queries that were never sent to the
+	// database. Setting it to IsTransientError retries more errors, but see
+	// IsTransientError for why that is only safe for idempotent queries.
+	Retryable func(err error) bool
+}
+
+// ExponentialBackoff returns a RetryPolicy.Backoff that waits base before the
+// first retry and doubles the wait for every retry after that, up to max.
+func ExponentialBackoff(base, max time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		wait := base
+		for i := 1; i < retry && wait < max; i++ {
+			wait *= 2
+		}
+		if wait > max {
+			wait = max
+		}
+		return wait
+	}
+}
+
+// transientErrorNumbers are the MySQL error numbers of errors that may succeed
+// if the query is run again.
+var transientErrorNumbers = map[uint64]bool{
+	1040: true, // ER_CON_COUNT_ERROR (too many connections)
+	1205: true, // ER_LOCK_WAIT_TIMEOUT
+	1213: true, // ER_LOCK_DEADLOCK
+	2006: true, // CR_SERVER_GONE_ERROR
+	2013: true, // CR_SERVER_LOST
+}
+
+// IsBadConnError reports whether err is driver.ErrBadConn, which a driver
+// returns when the connection was unusable before the query was sent. The
+// query cannot have run, so it is always safe to retry. It is the default
+// RetryPolicy.Retryable.
+func IsBadConnError(err error) bool {
+	return errors.Is(err, driver.ErrBadConn)
+}
+
+// IsTransientError reports whether err is an error that may succeed if the
+// query is run again: a bad connection, a network error, or a MySQL error
+// whose number is a lock wait timeout, deadlock or lost connection. It looks
+// for a Number field in the error chain (as found in the MySQL driver's
+// *mysql.MySQLError).
+//
+// Most of these errors can happen after the database has received the query,
+// so the query may already have run (and been committed) by the time the error
+// is returned. Retrying a query that is not idempotent, such as an INSERT or an
+// UPDATE that increments a column, can then apply the same write twice. Only
+// use IsTransientError as a RetryPolicy.Retryable for queries that are safe to
+// run more than once.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		// The MySQL driver's ErrInvalidConn
+		if e.Error() == "invalid connection" {
+			return true
+		}
+		v := reflect.ValueOf(e)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				continue
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		f := v.FieldByName("Number")
+		if !f.IsValid() {
+			continue
+		}
+		switch f.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if transientErrorNumbers[f.Uint()] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RetryDB wraps the DB so that its queries are retried according to the
+// RetryPolicy. Use it to apply a RetryPolicy to every query run with the DB;
+// to apply a RetryPolicy to a single query, use the query's Retry method.
+func RetryDB(db DB, policy RetryPolicy) DB {
+	return retryDB{db: db, policy: policy}
+}
+
+type retryDB struct {
+	db     DB
+	policy RetryPolicy
+}
+
+func (db retryDB) Query(query string, args ...interface{}) (rows *sql.Rows, err error) {
+	err = db.policy.do(nil, func() error {
+		rows, err = db.db.Query(query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (db retryDB) QueryContext(ctx context.Context, query string, args ...interface{}) (rows *sql.Rows, err error) {
+	err = db.policy.do(ctx, func() error {
+		rows, err = db.db.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (db retryDB) Exec(query string, args ...interface{}) (result sql.Result, err error) {
+	err = db.policy.do(nil, func() error {
+		result, err = db.db.Exec(query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (db retryDB) ExecContext(ctx context.Context, query string, args ...interface{}) (result sql.Result, err error) {
+	err = db.policy.do(ctx, func() error {
+		result, err = db.db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// do calls attempt until it succeeds, fails with an error that is not
+// retryable, or has been called MaxAttempts times. It stops waiting for the
+// next attempt if ctx is done.
+func (p RetryPolicy) do(ctx context.Context, attempt func() error) error {
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsBadConnError
+	}
+	var err error
+	for i := 0; ; i++ {
+		err = attempt()
+		if err == nil || i+1 >= p.MaxAttempts || !retryable(err) {
+			return err
+		}
+		var wait time.Duration
+		if p.Backoff != nil {
+			wait = p.Backoff(i + 1)
+		}
+		if ctx == nil {
+			time.Sleep(wait)
+			continue
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
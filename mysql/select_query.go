@@ -48,9 +48,11 @@ type SelectQuery struct {
 	// OFFSET
 	OffsetValue *int64
 	// DB
-	DB          DB
-	RowMapper   func(*Row)
-	Accumulator func()
+	DB             DB
+	PredicateGuard *PredicateGuard
+	RetryPolicy    *RetryPolicy
+	RowMapper      func(*Row)
+	Accumulator    func()
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -384,6 +386,20 @@ func (q SelectQuery) SelectRowx(mapper func(*Row)) SelectQuery {
 	return q
 }
 
+// Guard sets the PredicateGuard that the SelectQuery is checked (and possibly
+// amended) against when it is executed.
+func (q SelectQuery) Guard(guard PredicateGuard) SelectQuery {
+	q.PredicateGuard = &guard
+	return q
+}
+
+// Retry sets the RetryPolicy that is used to run the SelectQuery again if it fails
+// with a transient error.
+func (q SelectQuery) Retry(policy RetryPolicy) SelectQuery {
+	q.RetryPolicy = &policy
+	return q
+}
+
 // Fetch will run SelectQuery with the given DB. It then maps the results based
 // on the mapper function (and optionally runs the accumulator function).
 func (q SelectQuery) Fetch(db DB) (err error) {
@@ -406,6 +422,7 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	var rowcount int
 	defer func() {
 		if r := recover(); r != nil {
@@ -433,6 +450,7 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			logBuf.WriteString(strconv.Itoa(rowcount))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -450,6 +468,13 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	if len(q.SelectFields) == 0 {
 		q.SelectFields = Fields{FieldLiteral("1")}
 	}
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
@@ -1,6 +1,7 @@
 package sq
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -287,3 +288,51 @@ func TestStringField_Predicates(t *testing.T) {
 		})
 	}
 }
+
+func TestEnumField_ValidateEnums(t *testing.T) {
+	u := USERS()
+	status := NewEnumField("status", u.TableInfo, "active", "banned")
+	t.Run("valid values", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal([]string{"active", "banned"}, status.EnumValues())
+		_, args := InsertInto(u).Columns(u.USER_ID, status).Values(1, "active").ValidateEnums().ToSQL()
+		is.Equal([]interface{}{1, "active"}, args)
+		_, args = Update(u).Set(status.SetString("banned")).ValidateEnums().ToSQL()
+		is.Equal([]interface{}{"banned"}, args)
+	})
+	t.Run("invalid insert value", func(t *testing.T) {
+		is := is.New(t)
+		_, args := InsertInto(u).Valuesx(func(col *Column) {
+			col.SetInt(u.USER_ID, 1)
+			col.SetString(status, "deleted")
+		}).ValidateEnums().ToSQL()
+		is.Equal(1, len(args))
+		var enumErr *EnumError
+		is.True(errors.As(args[0].(error), &enumErr))
+		is.Equal("deleted", enumErr.Value)
+	})
+	t.Run("invalid update value", func(t *testing.T) {
+		is := is.New(t)
+		_, args := Update(u).Set(status.SetString("deleted")).ValidateEnums().ToSQL()
+		is.Equal(1, len(args))
+		var enumErr *EnumError
+		is.True(errors.As(args[0].(error), &enumErr))
+		is.Equal("deleted", enumErr.Value)
+	})
+	t.Run("fields for the same column are equal", func(t *testing.T) {
+		is := is.New(t)
+		other := NewEnumField("status", u.TableInfo, "active", "banned")
+		is.True(status == other)
+		is.Equal(status, other)
+		fields := map[Field]bool{status: true}
+		is.True(fields[other])
+		is.True(status != NewEnumField("status", u.TableInfo, "active"))
+		is.Equal([]string{""}, NewEnumField("status", u.TableInfo, "").EnumValues())
+		is.Equal(0, len(NewEnumField("status", u.TableInfo).EnumValues()))
+	})
+	t.Run("validation is opt-in", func(t *testing.T) {
+		is := is.New(t)
+		_, args := InsertInto(u).Columns(status).Values("deleted").ToSQL()
+		is.Equal([]interface{}{"deleted"}, args)
+	})
+}
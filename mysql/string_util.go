@@ -31,6 +31,33 @@ func expandValues(buf *strings.Builder, args *[]interface{}, excludedTableQualif
 	buf.WriteString(format)
 }
 
+// checkFormat returns an error if the format of a Fieldf or Predicatef looks
+// like it was built by concatenating values into SQL i.e. if it contains a
+// string literal, a statement separator or a comment. Values must be passed in
+// as ? arguments instead, and trusted SQL as an UnsafeLiteral. Double quotes
+// delimit string literals unless ANSI_QUOTES is set, so they are rejected as
+// well. Backtick quoted identifiers are skipped over.
+func checkFormat(format string) error {
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		switch {
+		case c == '`':
+			end := strings.IndexByte(format[i+1:], '`')
+			if end < 0 {
+				return fmt.Errorf("unsafe format %q: contains an unterminated quote, pass values as ? arguments instead", format)
+			}
+			i += end + 1
+		case c == '\'', c == '"':
+			return fmt.Errorf("unsafe format %q: contains a string literal, pass values as ? arguments instead", format)
+		case c == ';':
+			return fmt.Errorf("unsafe format %q: contains a statement separator, pass values as ? arguments instead", format)
+		case c == '#', strings.HasPrefix(format[i:], "--"), strings.HasPrefix(format[i:], "/*"):
+			return fmt.Errorf("unsafe format %q: contains a comment, pass values as ? arguments instead", format)
+		}
+	}
+	return nil
+}
+
 // appendSQLValue will write the SQL representation of the interface{} value
 // into the buffer and args slice. It propagates excludedTableQualifiers where
 // relevant.
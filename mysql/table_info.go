@@ -1,6 +1,9 @@
 package sq
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // TableInfo is struct that implements the Table interface, containing all the
 // information needed to call itself a Table. It is meant to be embedded in
@@ -9,6 +12,10 @@ type TableInfo struct {
 	Schema string
 	Name   string
 	Alias  string
+	// ReadOnly is set by sqgen for views that can neither be updated nor
+	// inserted into. Inserting into, updating or deleting from a read-only
+	// view fails with a ReadOnlyError before the query reaches the database.
+	ReadOnly bool
 }
 
 // AppendSQL marshals the TableInfo into a buffer and an args slice.
@@ -51,5 +58,32 @@ func (tbl *TableInfo) GetName() string {
 	return tbl.Name
 }
 
+// IsReadOnly reports whether the TableInfo is a read-only view.
+func (tbl *TableInfo) IsReadOnly() bool {
+	if tbl == nil {
+		return false
+	}
+	return tbl.ReadOnly
+}
+
+// ReadOnlyError is the error raised by an INSERT, UPDATE or DELETE that
+// targets a read-only view.
+type ReadOnlyError struct {
+	View      string
+	Statement string
+}
+
+// Error implements the error interface.
+func (e ReadOnlyError) Error() string {
+	return fmt.Sprintf("sq: cannot %s %s as it is a read-only view", e.Statement, e.View)
+}
+
+// checkWritable panics with a ReadOnlyError if the table is a read-only view.
+func checkWritable(table Table, statement string) {
+	if v, ok := table.(interface{ IsReadOnly() bool }); ok && v.IsReadOnly() {
+		panic(ReadOnlyError{View: table.GetName(), Statement: statement})
+	}
+}
+
 // AssertBaseTable implements the BaseTable interface.
 func (tbl *TableInfo) AssertBaseTable() {}
@@ -1,6 +1,7 @@
 package sq
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -40,3 +41,24 @@ func TestTableInfo_AppendSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestTableInfo_ReadOnly(t *testing.T) {
+	is := is.New(t)
+	view := &TableInfo{Schema: "public", Name: "user_stats", ReadOnly: true}
+	wantErr := func(statement string) error {
+		return ReadOnlyError{View: "user_stats", Statement: statement}
+	}
+	dbErr := errors.New("database reached")
+	_, args := InsertInto(view).Columns(FieldLiteral("a")).Values(1).ToSQL()
+	is.Equal([]interface{}{wantErr("INSERT INTO")}, args)
+	_, args = Update(view).Set(NewNumberField("a", view).SetInt(1)).ToSQL()
+	is.Equal([]interface{}{wantErr("UPDATE")}, args)
+	_, err := DeleteFrom(view).Exec(errDB{dbErr}, 0)
+	is.Equal(wantErr("DELETE FROM"), err)
+	is.Equal("sq: cannot DELETE FROM user_stats as it is a read-only view", err.Error())
+	// selecting from a read-only view is fine, as is writing to an updatable one
+	_, err = DeleteFrom(&TableInfo{Schema: "public", Name: "active_users"}).Exec(errDB{dbErr}, 0)
+	is.Equal(dbErr, err)
+	_, args = Select(FieldLiteral("a")).From(view).ToSQL()
+	is.Equal(0, len(args))
+}
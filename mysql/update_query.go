@@ -30,8 +30,13 @@ type UpdateQuery struct {
 	// LIMIT
 	LimitValue *int64
 	// DB
-	DB           DB
-	ColumnMapper func(*Column)
+	DB               DB
+	PredicateGuard   *PredicateGuard
+	RetryPolicy      *RetryPolicy
+	ConstraintErrors ConstraintErrors
+	ColumnMapper     func(*Column)
+	// Validation
+	EnumValidation bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -61,6 +66,9 @@ func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 		q.ColumnMapper(col)
 		q.Assignments = col.assignments
 	}
+	if q.EnumValidation {
+		validateEnumAssignments(q.Assignments)
+	}
 	// WITH
 	if !q.nested {
 		appendCTEs(buf, args, q.CTEs, nil, q.JoinTables)
@@ -70,6 +78,7 @@ func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	if q.UpdateTable == nil {
 		buf.WriteString("NULL")
 	} else {
+		checkWritable(q.UpdateTable, "UPDATE")
 		switch v := q.UpdateTable.(type) {
 		case Query:
 			buf.WriteString("(")
@@ -174,6 +183,13 @@ func (q UpdateQuery) Setx(mapper func(*Column)) UpdateQuery {
 	return q
 }
 
+// ValidateEnums makes the UpdateQuery check that every string assigned to an
+// EnumField is one of its allowed values, returning an *EnumError otherwise.
+func (q UpdateQuery) ValidateEnums() UpdateQuery {
+	q.EnumValidation = true
+	return q
+}
+
 // Join joins a new table to the UpdateQuery based on the predicates.
 func (q UpdateQuery) Join(table Table, predicate Predicate, predicates ...Predicate) UpdateQuery {
 	predicates = append([]Predicate{predicate}, predicates...)
@@ -258,6 +274,27 @@ func (q UpdateQuery) Limit(limit int) UpdateQuery {
 	return q
 }
 
+// MapConstraintErrors sets the errors to be returned by the UpdateQuery in place
+// of database errors that violate the named constraints.
+func (q UpdateQuery) MapConstraintErrors(errs ConstraintErrors) UpdateQuery {
+	q.ConstraintErrors = errs
+	return q
+}
+
+// Guard sets the PredicateGuard that the UpdateQuery is checked (and possibly
+// amended) against when it is executed.
+func (q UpdateQuery) Guard(guard PredicateGuard) UpdateQuery {
+	q.PredicateGuard = &guard
+	return q
+}
+
+// Retry sets the RetryPolicy that is used to run the UpdateQuery again if it fails
+// with a transient error.
+func (q UpdateQuery) Retry(policy RetryPolicy) UpdateQuery {
+	q.RetryPolicy = &policy
+	return q
+}
+
 // Exec will execute the UpdateQuery with the given DB. It will only compute
 // the rowsAffected if the ErowsAffected Execflag is passed to it.
 func (q UpdateQuery) Exec(db DB, flag ExecFlag) (rowsAffected int64, err error) {
@@ -276,6 +313,7 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	defer func() {
 		if r := recover(); r != nil {
 			switch v := r.(type) {
@@ -295,6 +333,7 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -307,6 +346,13 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 	}()
 	var res sql.Result
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return 0, err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
@@ -317,7 +363,7 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return rowsAffected, err
+		return rowsAffected, mapConstraintError(err, q.ConstraintErrors, q.UpdateTable)
 	}
 	if res != nil && ErowsAffected&flag != 0 {
 		rowsAffected, err = res.RowsAffected()
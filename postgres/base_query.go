@@ -1,8 +1,11 @@
 package sq
 
 import (
+	"database/sql"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 )
 
 // LogFlag is a flag that affects the verbosity of the Logger output.
@@ -13,6 +16,10 @@ const (
 	Linterpolate LogFlag = 1 << iota
 	Lstats
 	Lresults
+	// Lpool adds the connection pool statistics to the Lstats output when the
+	// DB is an *sql.DB: the connections in use when the query started, and
+	// how many times and for how long the pool was waited on while it ran.
+	Lpool
 	// Lparse
 	Lverbose = Lstats | Lresults
 )
@@ -31,10 +38,13 @@ var defaultLogger = log.New(os.Stdout, "[sq] ", log.Ldate|log.Ltime|log.Lshortfi
 // InsertQuery, UpdateQuery or DeleteQuery depending on the method that you
 // call on it.
 type BaseQuery struct {
-	DB      DB
-	Log     Logger
-	LogFlag LogFlag
-	CTEs    []CTE
+	DB             DB
+	PredicateGuard *PredicateGuard
+	RetryPolicy    *RetryPolicy
+	Log            Logger
+	LogFlag        LogFlag
+	CTEs           []CTE
+	Clock          Clock
 }
 
 // WithDefaultLog creates a new BaseQuery with the default logger and the LogFlag
@@ -72,6 +82,21 @@ func (q BaseQuery) WithDB(db DB) BaseQuery {
 	return q
 }
 
+// WithPredicateGuard adds the PredicateGuard to the BaseQuery. Every query
+// built from the BaseQuery is checked against the guard when it is executed.
+func (q BaseQuery) WithPredicateGuard(guard PredicateGuard) BaseQuery {
+	q.PredicateGuard = &guard
+	return q
+}
+
+// WithRetryPolicy adds the RetryPolicy to the BaseQuery. Every query built
+// from the BaseQuery is run again according to the RetryPolicy if it fails
+// with a transient error.
+func (q BaseQuery) WithRetryPolicy(policy RetryPolicy) BaseQuery {
+	q.RetryPolicy = &policy
+	return q
+}
+
 // With adds the CTEs to the BaseQuery
 func (q BaseQuery) With(CTEs ...CTE) BaseQuery {
 	q.CTEs = append(q.CTEs, CTEs...)
@@ -81,67 +106,79 @@ func (q BaseQuery) With(CTEs ...CTE) BaseQuery {
 // From transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) From(table Table) SelectQuery {
 	return SelectQuery{
-		FromTable: table,
-		CTEs:      q.CTEs,
-		DB:        q.DB,
-		Log:       q.Log,
-		LogFlag:   q.LogFlag,
+		FromTable:      table,
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
 	}
 }
 
 // Select transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) Select(fields ...Field) SelectQuery {
 	return SelectQuery{
-		SelectFields: fields,
-		CTEs:         q.CTEs,
-		DB:           q.DB,
-		Log:          q.Log,
-		LogFlag:      q.LogFlag,
+		SelectFields:   fields,
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
 	}
 }
 
 // SelectOne transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) SelectOne() SelectQuery {
 	return SelectQuery{
-		SelectFields: Fields{FieldLiteral("1")},
-		CTEs:         q.CTEs,
-		DB:           q.DB,
-		Log:          q.Log,
-		LogFlag:      q.LogFlag,
+		SelectFields:   Fields{FieldLiteral("1")},
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
 	}
 }
 
 // SelectAll transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) SelectAll() SelectQuery {
 	return SelectQuery{
-		SelectFields: Fields{FieldLiteral("*")},
-		CTEs:         q.CTEs,
-		DB:           q.DB,
-		Log:          q.Log,
-		LogFlag:      q.LogFlag,
+		SelectFields:   Fields{FieldLiteral("*")},
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
 	}
 }
 
 // SelectCount transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) SelectCount() SelectQuery {
 	return SelectQuery{
-		SelectFields: Fields{FieldLiteral("COUNT(*)")},
-		CTEs:         q.CTEs,
-		DB:           q.DB,
-		Log:          q.Log,
-		LogFlag:      q.LogFlag,
+		SelectFields:   Fields{FieldLiteral("COUNT(*)")},
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
 	}
 }
 
 // SelectDistinct transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) SelectDistinct(fields ...Field) SelectQuery {
 	return SelectQuery{
-		SelectType:   SelectTypeDistinct,
-		SelectFields: fields,
-		CTEs:         q.CTEs,
-		DB:           q.DB,
-		Log:          q.Log,
-		LogFlag:      q.LogFlag,
+		SelectType:     SelectTypeDistinct,
+		SelectFields:   fields,
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
 	}
 }
 
@@ -149,13 +186,15 @@ func (q BaseQuery) SelectDistinct(fields ...Field) SelectQuery {
 func (q BaseQuery) SelectDistinctOn(distinctFields ...Field) func(...Field) SelectQuery {
 	return func(fields ...Field) SelectQuery {
 		return SelectQuery{
-			SelectType:   SelectTypeDistinctOn,
-			SelectFields: fields,
-			DistinctOn:   distinctFields,
-			CTEs:         q.CTEs,
-			DB:           q.DB,
-			Log:          q.Log,
-			LogFlag:      q.LogFlag,
+			SelectType:     SelectTypeDistinctOn,
+			SelectFields:   fields,
+			DistinctOn:     distinctFields,
+			CTEs:           q.CTEs,
+			DB:             q.DB,
+			PredicateGuard: q.PredicateGuard,
+			RetryPolicy:    q.RetryPolicy,
+			Log:            q.Log,
+			LogFlag:        q.LogFlag,
 		}
 	}
 }
@@ -163,56 +202,108 @@ func (q BaseQuery) SelectDistinctOn(distinctFields ...Field) func(...Field) Sele
 // Selectx transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) Selectx(mapper func(*Row), accumulator func()) SelectQuery {
 	return SelectQuery{
-		RowMapper:   mapper,
-		Accumulator: accumulator,
-		CTEs:        q.CTEs,
-		DB:          q.DB,
-		Log:         q.Log,
-		LogFlag:     q.LogFlag,
+		RowMapper:      mapper,
+		Accumulator:    accumulator,
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
 	}
 }
 
 // SelectRowx transforms the BaseQuery into a SelectQuery.
 func (q BaseQuery) SelectRowx(mapper func(*Row)) SelectQuery {
 	return SelectQuery{
-		RowMapper: mapper,
-		CTEs:      q.CTEs,
-		DB:        q.DB,
-		Log:       q.Log,
-		LogFlag:   q.LogFlag,
+		RowMapper:      mapper,
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
 	}
 }
 
 // InsertInto transforms the BaseQuery into an InsertQuery.
 func (q BaseQuery) InsertInto(table BaseTable) InsertQuery {
 	return InsertQuery{
-		IntoTable: table,
-		CTEs:      q.CTEs,
-		DB:        q.DB,
-		Log:       q.Log,
-		LogFlag:   q.LogFlag,
+		IntoTable:      table,
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
 	}
 }
 
 // Update transforms the BaseQuery into an UpdateQuery.
 func (q BaseQuery) Update(table BaseTable) UpdateQuery {
 	return UpdateQuery{
-		UpdateTable: table,
-		CTEs:        q.CTEs,
-		DB:          q.DB,
-		Log:         q.Log,
-		LogFlag:     q.LogFlag,
+		UpdateTable:    table,
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
 	}
 }
 
 // DeleteFrom transforms the BaseQuery into a DeleteQuery.
 func (q BaseQuery) DeleteFrom(table BaseTable) DeleteQuery {
 	return DeleteQuery{
-		FromTable: table,
-		CTEs:      q.CTEs,
-		DB:        q.DB,
-		Log:       q.Log,
-		LogFlag:   q.LogFlag,
+		FromTable:      table,
+		CTEs:           q.CTEs,
+		DB:             q.DB,
+		PredicateGuard: q.PredicateGuard,
+		RetryPolicy:    q.RetryPolicy,
+		Log:            q.Log,
+		LogFlag:        q.LogFlag,
+	}
+}
+
+// RefreshMaterializedView transforms the BaseQuery into a
+// RefreshMaterializedViewQuery.
+func (q BaseQuery) RefreshMaterializedView(view MaterializedView) RefreshMaterializedViewQuery {
+	return RefreshMaterializedViewQuery{
+		View:    view,
+		DB:      q.DB,
+		Log:     q.Log,
+		LogFlag: q.LogFlag,
+	}
+}
+
+// Analyze transforms the BaseQuery into an AnalyzeQuery.
+func (q BaseQuery) Analyze(tables ...BaseTable) AnalyzeQuery {
+	return AnalyzeQuery{
+		Tables:  tables,
+		DB:      q.DB,
+		Log:     q.Log,
+		LogFlag: q.LogFlag,
+	}
+}
+
+// Vacuum transforms the BaseQuery into a VacuumQuery.
+func (q BaseQuery) Vacuum(tables ...BaseTable) VacuumQuery {
+	return VacuumQuery{
+		Tables:  tables,
+		DB:      q.DB,
+		Log:     q.Log,
+		LogFlag: q.LogFlag,
+	}
+}
+
+// CreateIndex transforms the BaseQuery into a CreateIndexQuery.
+func (q BaseQuery) CreateIndex(name string, table BaseTable) CreateIndexQuery {
+	return CreateIndexQuery{
+		Name:    name,
+		Table:   table,
+		DB:      q.DB,
+		Log:     q.Log,
+		LogFlag: q.LogFlag,
 	}
 }
 
@@ -239,3 +330,46 @@ func (q BaseQuery) UnionAll(queries ...Query) VariadicQuery {
 		LogFlag:  q.LogFlag,
 	}
 }
+
+// poolStats returns a snapshot of the connection pool statistics of the DB if
+// the Lpool LogFlag is set and the DB is an *sql.DB, otherwise it returns nil.
+func poolStats(flag LogFlag, db DB) *sql.DBStats {
+	if Lpool&flag == 0 {
+		return nil
+	}
+	if retry, ok := db.(retryDB); ok {
+		db = retry.db
+	}
+	sqlDB, ok := db.(*sql.DB)
+	if !ok {
+		return nil
+	}
+	stats := sqlDB.Stats()
+	return &stats
+}
+
+// appendPoolStats writes the connection pool statistics into the buffer, given
+// the snapshots that poolStats took before and after the query ran. The
+// connections in use and idle are those from before the query took its
+// connection, and the waits are the ones that happened while the query ran.
+// Since the pool is shared, the waits include those of queries run
+// concurrently. It writes nothing if either snapshot is nil.
+func appendPoolStats(buf *strings.Builder, before, after *sql.DBStats) {
+	if before == nil || after == nil {
+		return
+	}
+	buf.WriteString(", pool: ")
+	buf.WriteString(strconv.Itoa(before.InUse))
+	buf.WriteString(" in use, ")
+	buf.WriteString(strconv.Itoa(before.Idle))
+	buf.WriteString(" idle")
+	if before.MaxOpenConnections > 0 {
+		buf.WriteString(", ")
+		buf.WriteString(strconv.Itoa(before.MaxOpenConnections))
+		buf.WriteString(" max open")
+	}
+	buf.WriteString(", ")
+	buf.WriteString(strconv.FormatInt(after.WaitCount-before.WaitCount, 10))
+	buf.WriteString(" waits totalling ")
+	buf.WriteString((after.WaitDuration - before.WaitDuration).String())
+}
@@ -1,8 +1,11 @@
 package sq
 
 import (
+	"database/sql"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -95,3 +98,24 @@ func TestBaseQuery(t *testing.T) {
 	del.AppendSQL(buf, &args, nil)
 	is.Equal("DELETE FROM NULL", buf.String())
 }
+
+func TestAppendPoolStats(t *testing.T) {
+	is := is.New(t)
+	db, err := sql.Open("txdb", "AppendPoolStats")
+	is.NoErr(err)
+	defer db.Close()
+	db.SetMaxOpenConns(10)
+	buf := &strings.Builder{}
+	is.True(poolStats(Lstats, db) == nil) // Lpool not set
+	is.True(poolStats(Lstats|Lpool, errDB{errors.New("not an *sql.DB")}) == nil)
+	before := poolStats(Lstats|Lpool, RetryDB(db, RetryPolicy{}))
+	is.Equal(&sql.DBStats{MaxOpenConnections: 10}, before)
+	appendPoolStats(buf, nil, before)
+	is.Equal("", buf.String())
+	// The connections in use are from before the query, the waits are the
+	// ones during the query
+	before = &sql.DBStats{MaxOpenConnections: 10, InUse: 10, WaitCount: 5, WaitDuration: time.Second}
+	after := &sql.DBStats{MaxOpenConnections: 10, InUse: 3, Idle: 7, WaitCount: 7, WaitDuration: 3 * time.Second}
+	appendPoolStats(buf, before, after)
+	is.Equal(", pool: 10 in use, 0 idle, 10 max open, 2 waits totalling 2s", buf.String())
+}
@@ -0,0 +1,45 @@
+package sq
+
+import "time"
+
+// Clock returns the current time. A BaseQuery with a Clock renders the
+// TimeFields returned by its Now method as a time.Time argument obtained from
+// the Clock instead of the database's NOW(), which makes the generated query
+// and its args deterministic under test. Since the Clock belongs to the
+// BaseQuery, tests that run in parallel can each use their own.
+//
+// There are no audit columns that the query builders fill in by themselves:
+// created_at and updated_at columns are set explicitly, e.g. with
+// u.UPDATED_AT.Set(base.Now()).
+type Clock func() time.Time
+
+// FrozenClock returns a Clock that always returns t.
+func FrozenClock(t time.Time) Clock {
+	return func() time.Time { return t }
+}
+
+// WithClock creates a new BaseQuery with the Clock.
+func WithClock(clock Clock) BaseQuery {
+	return BaseQuery{
+		Clock: clock,
+	}
+}
+
+// WithClock adds the Clock to the BaseQuery.
+func (q BaseQuery) WithClock(clock Clock) BaseQuery {
+	q.Clock = clock
+	return q
+}
+
+// Now returns a TimeField representing the current time according to the
+// BaseQuery's Clock. It is rendered as NOW() if the BaseQuery has no Clock.
+func (q BaseQuery) Now() TimeField {
+	if q.Clock == nil {
+		return Now()
+	}
+	clock := q.Clock
+	return TimeField{
+		now:   true,
+		clock: &clock,
+	}
+}
@@ -1,6 +1,7 @@
 package sq
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -54,6 +55,138 @@ func TestColumnInsert(t *testing.T) {
 	)
 }
 
+func TestColumnInsertOrder(t *testing.T) {
+	u := USERS()
+	t.Run("columns set in different orders are normalized", func(t *testing.T) {
+		is := is.New(t)
+		col := &Column{mode: colmodeInsert}
+		col.Set(u.USER_ID, 1)
+		col.Set(u.DISPLAYNAME, "one")
+		col.Set(u.EMAIL, "one@email.com")
+		col.Set(u.EMAIL, "two@email.com")
+		col.Set(u.USER_ID, 2)
+		col.Set(u.DISPLAYNAME, "two")
+		col.Set(u.DISPLAYNAME, "three")
+		col.Set(u.EMAIL, "three@email.com")
+		col.Set(u.USER_ID, 3)
+		col.endRow()
+		is.Equal(Fields{u.USER_ID, u.DISPLAYNAME, u.EMAIL}, col.insertColumns)
+		is.Equal(
+			RowValues{
+				{1, "one", "one@email.com"},
+				{2, "two", "two@email.com"},
+				{3, "three", "three@email.com"},
+			},
+			col.rowValues,
+		)
+	})
+	t.Run("column missing from the first row", func(t *testing.T) {
+		is := is.New(t)
+		defer func() {
+			is.Equal(errors.New("column password was not set in the first row"), recover())
+		}()
+		col := &Column{mode: colmodeInsert}
+		col.Set(u.USER_ID, 1)
+		col.Set(u.DISPLAYNAME, "one")
+		col.Set(u.USER_ID, 2)
+		col.Set(u.PASSWORD, "two")
+	})
+	t.Run("column missing from a later row", func(t *testing.T) {
+		is := is.New(t)
+		defer func() {
+			is.Equal(errors.New("column displayname in row 2 was not set"), recover())
+		}()
+		col := &Column{mode: colmodeInsert}
+		col.Set(u.USER_ID, 1)
+		col.Set(u.DISPLAYNAME, "one")
+		col.Set(u.USER_ID, 2)
+		col.Set(u.USER_ID, 3)
+		col.Set(u.DISPLAYNAME, "three")
+	})
+	t.Run("FillNull", func(t *testing.T) {
+		is := is.New(t)
+		col := &Column{mode: colmodeInsert, fill: FillNull}
+		col.NextRow()
+		col.Set(u.USER_ID, 1)
+		col.Set(u.DISPLAYNAME, "one")
+		col.NextRow()
+		col.Set(u.USER_ID, 2)
+		col.Set(u.EMAIL, "two@email.com")
+		col.NextRow()
+		col.Set(u.USER_ID, 3)
+		col.endRow()
+		is.Equal(Fields{u.USER_ID, u.DISPLAYNAME, u.EMAIL}, col.insertColumns)
+		is.Equal(
+			RowValues{
+				{1, "one", nil},
+				{2, nil, "two@email.com"},
+				{3, nil, nil},
+			},
+			col.rowValues,
+		)
+	})
+	t.Run("FillDefault", func(t *testing.T) {
+		is := is.New(t)
+		col := &Column{mode: colmodeInsert, fill: FillDefault}
+		col.Set(u.USER_ID, 1)
+		col.NextRow()
+		col.Set(u.USER_ID, 2)
+		col.Set(u.DISPLAYNAME, "two")
+		col.endRow()
+		is.Equal(Fields{u.USER_ID, u.DISPLAYNAME}, col.insertColumns)
+		is.Equal(RowValues{{1, FieldLiteral("DEFAULT")}, {2, "two"}}, col.rowValues)
+	})
+	t.Run("rows that skip columns", func(t *testing.T) {
+		is := is.New(t)
+		col := &Column{mode: colmodeInsert, fill: FillNull}
+		col.Set(u.USER_ID, 1)
+		col.NextRow()
+		col.Set(u.DISPLAYNAME, "two")
+		col.Set(u.USER_ID, 2)
+		col.NextRow()
+		col.Set(u.DISPLAYNAME, "three")
+		col.endRow()
+		is.Equal(Fields{u.USER_ID, u.DISPLAYNAME}, col.insertColumns)
+		is.Equal(RowValues{{1, nil}, {2, "two"}, {nil, "three"}}, col.rowValues)
+	})
+	t.Run("rows that skip columns without NextRow", func(t *testing.T) {
+		is := is.New(t)
+		defer func() {
+			is.Equal(errors.New("column user_id was set twice in row 1, rows that skip columns must be separated with NextRow"), recover())
+		}()
+		// Without NextRow, these three rows would be read as two rows that
+		// set both columns
+		col := &Column{mode: colmodeInsert, fill: FillNull}
+		col.Set(u.USER_ID, 1)
+		col.Set(u.DISPLAYNAME, "two")
+		col.Set(u.USER_ID, 2)
+		col.Set(u.DISPLAYNAME, "three")
+	})
+	t.Run("column set twice in a row separated with NextRow", func(t *testing.T) {
+		is := is.New(t)
+		defer func() {
+			is.Equal(errors.New("column displayname was set twice in row 2, rows that skip columns must be separated with NextRow"), recover())
+		}()
+		col := &Column{mode: colmodeInsert}
+		col.Set(u.USER_ID, 1)
+		col.Set(u.DISPLAYNAME, "one")
+		col.NextRow()
+		col.Set(u.DISPLAYNAME, "two")
+		col.Set(u.DISPLAYNAME, "three")
+	})
+	t.Run("column missing from the last row", func(t *testing.T) {
+		is := is.New(t)
+		defer func() {
+			is.Equal(errors.New("column displayname in row 2 was not set"), recover())
+		}()
+		col := &Column{mode: colmodeInsert}
+		col.Set(u.USER_ID, 1)
+		col.Set(u.DISPLAYNAME, "one")
+		col.Set(u.USER_ID, 2)
+		col.endRow()
+	})
+}
+
 func TestColumnUpdate(t *testing.T) {
 	is := is.New(t)
 	type User struct {
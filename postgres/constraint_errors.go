@@ -0,0 +1,82 @@
+package sq
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+)
+
+// ConstraintErrors maps constraint names to the errors that should be returned
+// in place of any database error that violates them e.g.
+// ConstraintErrors{"users_email_key": ErrEmailTaken}.
+type ConstraintErrors map[string]error
+
+// ConstraintErrorTable is implemented by tables that map their constraint names
+// to errors. Since generated tables should not be edited, the method can be
+// added to the table type in a separate file of the same package. The table's
+// ConstraintErrors are consulted after the query's own ConstraintErrors.
+type ConstraintErrorTable interface {
+	BaseTable
+	ConstraintErrors() ConstraintErrors
+}
+
+var constraintMessageRegexp = regexp.MustCompile(`constraint "([^"]+)"`)
+
+// constraintName returns the name of the constraint violated by err, or an
+// empty string if it cannot be determined. It looks for a Constraint or
+// ConstraintName field in the error chain (as found in lib/pq's *pq.Error and
+// pgx's *pgconn.PgError) before falling back to parsing the error message.
+func constraintName(err error) string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		v := reflect.ValueOf(e)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				continue
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		for _, name := range []string{"Constraint", "ConstraintName"} {
+			f := v.FieldByName(name)
+			if f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+				return f.String()
+			}
+		}
+	}
+	if match := constraintMessageRegexp.FindStringSubmatch(err.Error()); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// mapConstraintError returns the error mapped to the constraint violated by
+// err, checking errs before the ConstraintErrors of the tables. If there is no
+// mapping for the constraint, err is returned as is.
+func mapConstraintError(err error, errs ConstraintErrors, tables ...BaseTable) error {
+	if err == nil {
+		return nil
+	}
+	var name string
+	lookup := func(errs ConstraintErrors) error {
+		if len(errs) == 0 {
+			return nil
+		}
+		if name == "" {
+			name = constraintName(err)
+		}
+		return errs[name]
+	}
+	if mapped := lookup(errs); mapped != nil {
+		return mapped
+	}
+	for _, table := range tables {
+		if table, ok := table.(ConstraintErrorTable); ok {
+			if mapped := lookup(table.ConstraintErrors()); mapped != nil {
+				return mapped
+			}
+		}
+	}
+	return err
+}
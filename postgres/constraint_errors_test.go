@@ -0,0 +1,115 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type constraintTestError struct {
+	Message    string
+	Constraint string
+}
+
+func (e *constraintTestError) Error() string { return e.Message }
+
+type constraintTestTable struct{ TABLE_USERS }
+
+var errEmailTaken = errors.New("email taken")
+
+func (tbl constraintTestTable) ConstraintErrors() ConstraintErrors {
+	return ConstraintErrors{"users_email_key": errEmailTaken}
+}
+
+// errDB is a DB that fails every query with err.
+type errDB struct{ err error }
+
+func (db errDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, db.err
+}
+
+func (db errDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, db.err
+}
+
+func (db errDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return nil, db.err
+}
+
+func (db errDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, db.err
+}
+
+func TestConstraintName(t *testing.T) {
+	type TT struct {
+		description string
+		err         error
+		want        string
+	}
+	tests := []TT{
+		{"no constraint", errors.New("connection refused"), ""},
+		{"constraint field", &constraintTestError{Message: "violation", Constraint: "users_email_key"}, "users_email_key"},
+		{
+			"wrapped constraint field",
+			fmt.Errorf("insert: %w", &constraintTestError{Message: "violation", Constraint: "users_email_key"}),
+			"users_email_key",
+		},
+		{
+			"error message",
+			errors.New(`pq: duplicate key value violates unique constraint "users_email_key"`),
+			"users_email_key",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(tt.want, constraintName(tt.err))
+		})
+	}
+}
+
+func TestMapConstraintError(t *testing.T) {
+	errTaken := errors.New("taken")
+	dbErr := &constraintTestError{Message: "violation", Constraint: "users_email_key"}
+	t.Run("nil", func(t *testing.T) {
+		is := is.New(t)
+		is.NoErr(mapConstraintError(nil, ConstraintErrors{"users_email_key": errTaken}))
+	})
+	t.Run("query mapping", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(errTaken, mapConstraintError(dbErr, ConstraintErrors{"users_email_key": errTaken}, USERS()))
+	})
+	t.Run("query mapping before table mapping", func(t *testing.T) {
+		is := is.New(t)
+		tbl := constraintTestTable{USERS()}
+		is.Equal(errTaken, mapConstraintError(dbErr, ConstraintErrors{"users_email_key": errTaken}, tbl))
+	})
+	t.Run("table mapping", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(errEmailTaken, mapConstraintError(dbErr, nil, constraintTestTable{USERS()}))
+	})
+	t.Run("unmapped constraint", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(dbErr, mapConstraintError(dbErr, ConstraintErrors{"users_pkey": errTaken}, USERS()))
+	})
+	t.Run("Exec", func(t *testing.T) {
+		is := is.New(t)
+		u := constraintTestTable{USERS()}
+		_, err := InsertInto(u).
+			Columns(u.EMAIL).
+			Values("bob@email.com").
+			Exec(errDB{dbErr}, 0)
+		is.Equal(errEmailTaken, err)
+		err = Update(u).
+			Set(u.EMAIL.SetString("bob@email.com")).
+			MapConstraintErrors(ConstraintErrors{"users_email_key": errTaken}).
+			ReturningRowx(func(row *Row) {}).
+			Fetch(errDB{dbErr})
+		is.Equal(errTaken, err)
+	})
+}
@@ -0,0 +1,270 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CreateIndexQuery represents a CREATE INDEX query.
+type CreateIndexQuery struct {
+	nested bool
+	// CREATE INDEX
+	IsUnique       bool
+	IsConcurrent   bool
+	IsIfNotExists  bool
+	Name           string
+	Table          BaseTable
+	Method         string
+	IndexedFields  Fields
+	ProgressReport func(IndexProgress)
+	ProgressEvery  time.Duration
+	// DB
+	DB DB
+	// Logging
+	Log     Logger
+	LogFlag LogFlag
+	logSkip int
+}
+
+// IndexProgress is the progress of a CREATE INDEX query, as reported by
+// pg_stat_progress_create_index. The totals are 0 when they do not apply to
+// the current phase.
+type IndexProgress struct {
+	Phase        string
+	LockersTotal int64
+	LockersDone  int64
+	BlocksTotal  int64
+	BlocksDone   int64
+	TuplesTotal  int64
+	TuplesDone   int64
+}
+
+// ToSQL marshals the CreateIndexQuery into a query string and args slice.
+func (q CreateIndexQuery) ToSQL() (string, []interface{}) {
+	q.logSkip += 1
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args, nil)
+	return buf.String(), args
+}
+
+// AppendSQL marshals the CreateIndexQuery into a buffer and args slice.
+func (q CreateIndexQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params map[string]int) {
+	// CREATE INDEX
+	buf.WriteString("CREATE ")
+	if q.IsUnique {
+		buf.WriteString("UNIQUE ")
+	}
+	buf.WriteString("INDEX ")
+	if q.IsConcurrent {
+		buf.WriteString("CONCURRENTLY ")
+	}
+	if q.IsIfNotExists {
+		buf.WriteString("IF NOT EXISTS ")
+	}
+	if q.Name != "" {
+		if strings.ContainsAny(q.Name, " \t") {
+			buf.WriteString(`"`)
+			buf.WriteString(q.Name)
+			buf.WriteString(`" `)
+		} else {
+			buf.WriteString(q.Name + " ")
+		}
+	}
+	// ON
+	buf.WriteString("ON ")
+	if q.Table == nil {
+		buf.WriteString("NULL")
+	} else {
+		checkNotOnly(q.Table, "CREATE INDEX")
+		q.Table.AppendSQL(buf, args, nil)
+	}
+	// USING
+	if q.Method != "" {
+		buf.WriteString(" USING " + q.Method)
+	}
+	// (columns)
+	buf.WriteString(" (")
+	var excludedTableQualifiers []string
+	if q.Table != nil {
+		excludedTableQualifiers = []string{getAliasOrName(q.Table)}
+	}
+	start := len(*args)
+	for i, field := range q.IndexedFields {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if field == nil {
+			buf.WriteString("NULL")
+			continue
+		}
+		field.AppendSQLExclude(buf, args, nil, excludedTableQualifiers)
+	}
+	buf.WriteString(")")
+	if len(*args) > start {
+		// DDL statements cannot be prepared, so there is nothing to bind the
+		// args to
+		panic(errors.New("CREATE INDEX columns cannot contain values that need to be passed in as args"))
+	}
+	if !q.nested {
+		logUtility(buf.String(), q.Log, q.LogFlag, q.logSkip+1)
+	}
+}
+
+// NestThis indicates to the CreateIndexQuery that it is nested.
+func (q CreateIndexQuery) NestThis() Query {
+	q.nested = true
+	return q
+}
+
+// CreateIndex creates a new CreateIndexQuery. If name is empty, the database
+// chooses a name for the index.
+func CreateIndex(name string, table BaseTable) CreateIndexQuery {
+	return CreateIndexQuery{
+		Name:  name,
+		Table: table,
+	}
+}
+
+// Columns sets the columns (or expressions) that the index is built on.
+func (q CreateIndexQuery) Columns(fields ...Field) CreateIndexQuery {
+	q.IndexedFields = fields
+	return q
+}
+
+// Unique makes the index a unique index.
+func (q CreateIndexQuery) Unique() CreateIndexQuery {
+	q.IsUnique = true
+	return q
+}
+
+// Concurrently builds the index without locking out writes to the table. It
+// takes longer and cannot be run inside a transaction, so the DB must not be
+// an *sql.Tx. If it fails it leaves behind an invalid index which must be
+// dropped before trying again.
+func (q CreateIndexQuery) Concurrently() CreateIndexQuery {
+	q.IsConcurrent = true
+	return q
+}
+
+// IfNotExists makes the CreateIndexQuery do nothing if an index with the same
+// name already exists.
+func (q CreateIndexQuery) IfNotExists() CreateIndexQuery {
+	q.IsIfNotExists = true
+	return q
+}
+
+// Using sets the index method e.g. "btree", "hash", "gin" or "gist".
+func (q CreateIndexQuery) Using(method string) CreateIndexQuery {
+	q.Method = method
+	return q
+}
+
+// WithProgress makes the CreateIndexQuery poll pg_stat_progress_create_index
+// every interval while the index is being built and pass the progress to
+// report. The polling runs on a separate connection, so the DB must be a
+// connection pool like *sql.DB. Errors while polling are ignored.
+func (q CreateIndexQuery) WithProgress(interval time.Duration, report func(IndexProgress)) CreateIndexQuery {
+	q.ProgressEvery = interval
+	q.ProgressReport = report
+	return q
+}
+
+// Exec will execute the CreateIndexQuery with the given DB.
+func (q CreateIndexQuery) Exec(db DB) (err error) {
+	q.logSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+// ExecContext will execute the CreateIndexQuery with the given DB and context.
+func (q CreateIndexQuery) ExecContext(ctx context.Context, db DB) (err error) {
+	if db == nil {
+		if q.DB == nil {
+			return errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	var watch func() (stop func())
+	if q.ProgressReport != nil && q.ProgressEvery > 0 && q.Table != nil {
+		watch = func() (stop func()) {
+			// Resolve the table name here so that if it panics, the panic is
+			// recovered by execUtility instead of crashing the polling
+			// goroutine
+			buf := &strings.Builder{}
+			q.Table.AppendSQL(buf, &[]interface{}{}, nil)
+			tableName := buf.String()
+			done := make(chan struct{})
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				pollIndexProgress(ctx, db, tableName, q.ProgressEvery, q.ProgressReport, done)
+			}()
+			return func() {
+				close(done)
+				wg.Wait()
+			}
+		}
+	}
+	q.logSkip += 2
+	return execUtility(ctx, db, q, q.Log, q.LogFlag, q.logSkip, "Created index", watch)
+}
+
+// pollIndexProgress reports the progress of the index being built on the
+// table every interval until done is closed.
+func pollIndexProgress(ctx context.Context, db DB, tableName string, interval time.Duration, report func(IndexProgress), done <-chan struct{}) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	query := "SELECT phase, lockers_total, lockers_done, blocks_total, blocks_done, tuples_total, tuples_done" +
+		" FROM pg_stat_progress_create_index WHERE relid = $1::regclass"
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		progress, err := queryIndexProgress(ctx, db, query, tableName)
+		if err != nil || progress == nil {
+			continue
+		}
+		select {
+		case <-done:
+			return
+		default:
+			report(*progress)
+		}
+	}
+}
+
+// queryIndexProgress returns the progress of the index being built on the
+// table, or nil if no index is being built.
+func queryIndexProgress(ctx context.Context, db DB, query, tableName string) (*IndexProgress, error) {
+	rows, err := db.QueryContext(ctx, query, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	var progress IndexProgress
+	var lockersTotal, lockersDone, blocksTotal, blocksDone, tuplesTotal, tuplesDone sql.NullInt64
+	err = rows.Scan(&progress.Phase, &lockersTotal, &lockersDone, &blocksTotal, &blocksDone, &tuplesTotal, &tuplesDone)
+	if err != nil {
+		return nil, fmt.Errorf("could not scan pg_stat_progress_create_index: %w", err)
+	}
+	progress.LockersTotal, progress.LockersDone = lockersTotal.Int64, lockersDone.Int64
+	progress.BlocksTotal, progress.BlocksDone = blocksTotal.Int64, blocksDone.Int64
+	progress.TuplesTotal, progress.TuplesDone = tuplesTotal.Int64, tuplesDone.Int64
+	return &progress, rows.Err()
+}
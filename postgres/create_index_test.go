@@ -0,0 +1,145 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+// slowExecDB is an errDB whose ExecContext takes a while, and which records the
+// queries that are run while it does. If rowsDB is not nil, the queries are run
+// against it instead of returning the error.
+type slowExecDB struct {
+	errDB
+	mu      *sync.Mutex
+	queries *[]string
+	rowsDB  *sql.DB
+}
+
+func (db slowExecDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	time.Sleep(50 * time.Millisecond)
+	return db.errDB.ExecContext(ctx, query, args...)
+}
+
+func (db slowExecDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	*db.queries = append(*db.queries, query)
+	if db.rowsDB != nil {
+		return db.rowsDB.QueryContext(ctx, query, args...)
+	}
+	return db.errDB.QueryContext(ctx, query, args...)
+}
+
+func TestCreateIndexQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		description string
+		q           CreateIndexQuery
+		wantQuery   string
+	}
+	u := USERS()
+	tests := []TT{
+		{
+			"basic",
+			CreateIndex("users_email_idx", u).Columns(u.EMAIL),
+			"CREATE INDEX users_email_idx ON public.users (email)",
+		},
+		{
+			"quoted name",
+			CreateIndex("users email idx", u).Columns(u.EMAIL),
+			`CREATE INDEX "users email idx" ON public.users (email)`,
+		},
+		{
+			"all options",
+			CreateIndex("users_name_email_idx", u).Unique().Concurrently().IfNotExists().Using("btree").Columns(u.DISPLAYNAME, u.EMAIL),
+			"CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS users_name_email_idx ON public.users USING btree (displayname, email)",
+		},
+		{
+			"aliased table, expression and unnamed index",
+			func() CreateIndexQuery {
+				u := USERS().As("u")
+				return CreateIndex("", u).Columns(Fieldf("lower(?)", u.EMAIL), u.USER_ID.Desc())
+			}(),
+			"CREATE INDEX ON public.users (lower(email), user_id DESC)",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(0, len(gotArgs))
+		})
+	}
+}
+
+func TestCreateIndexQuery_Exec(t *testing.T) {
+	u := USERS()
+	dbErr := errors.New("database reached")
+	t.Run("args are rejected", func(t *testing.T) {
+		is := is.New(t)
+		err := CreateIndex("users_lower_email_idx", u).Columns(Fieldf("coalesce(?, ?)", u.EMAIL, "")).Exec(errDB{dbErr})
+		is.True(err != nil)
+		is.True(err != dbErr)
+	})
+	t.Run("progress is polled while the index is built", func(t *testing.T) {
+		is := is.New(t)
+		db := slowExecDB{errDB: errDB{dbErr}, mu: &sync.Mutex{}, queries: &[]string{}}
+		var reports int
+		err := WithDB(db).CreateIndex("users_email_idx", u).
+			Concurrently().
+			Columns(u.EMAIL).
+			WithProgress(5*time.Millisecond, func(IndexProgress) { reports++ }).
+			ExecContext(context.Background(), nil)
+		is.Equal(dbErr, err)
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		is.True(len(*db.queries) > 0)
+		is.Equal("SELECT phase, lockers_total, lockers_done, blocks_total, blocks_done, tuples_total, tuples_done"+
+			" FROM pg_stat_progress_create_index WHERE relid = $1::regclass", (*db.queries)[0])
+		is.Equal(0, reports) // polling errors are not reported
+	})
+	t.Run("progress is reported", func(t *testing.T) {
+		is := is.New(t)
+		rowsDB := openRowDB(
+			[]string{"phase", "lockers_total", "lockers_done", "blocks_total", "blocks_done", "tuples_total", "tuples_done"},
+			[]driver.Value{"building index: scanning table", nil, nil, int64(100), int64(40), int64(0), int64(0)},
+		)
+		defer rowsDB.Close()
+		db := slowExecDB{errDB: errDB{dbErr}, mu: &sync.Mutex{}, queries: &[]string{}, rowsDB: rowsDB}
+		var mu sync.Mutex
+		var reports []IndexProgress
+		err := CreateIndex("users_email_idx", u).
+			Columns(u.EMAIL).
+			WithProgress(5*time.Millisecond, func(progress IndexProgress) {
+				mu.Lock()
+				defer mu.Unlock()
+				reports = append(reports, progress)
+			}).
+			ExecContext(context.Background(), db)
+		is.Equal(dbErr, err)
+		mu.Lock()
+		defer mu.Unlock()
+		is.True(len(reports) > 0)
+		is.Equal(IndexProgress{Phase: "building index: scanning table", BlocksTotal: 100, BlocksDone: 40}, reports[0])
+	})
+	t.Run("table name that cannot be resolved", func(t *testing.T) {
+		is := is.New(t)
+		resolverErr := errors.New("unknown tenant")
+		users := &TableInfo{Schema: "tenant_template", Name: "users", TenantID: "42"}
+		users.SchemaResolver = SchemaResolverFunc(func(string) (string, error) { return "", resolverErr })
+		db := slowExecDB{errDB: errDB{dbErr}, mu: &sync.Mutex{}, queries: &[]string{}}
+		err := CreateIndex("users_email_idx", users).
+			Columns(FieldLiteral("email")).
+			WithProgress(5*time.Millisecond, func(IndexProgress) {}).
+			Exec(db)
+		is.True(errors.Is(err, resolverErr))
+	})
+}
@@ -205,6 +205,20 @@ func TestCTE(t *testing.T) {
 			tt.wantArgs = []interface{}{1, "apple", 2, 3}
 			return tt
 		}(),
+		func() TT {
+			var tt TT
+			tt.description = "CTE chain (columns propagate without aliases)"
+			u := USERS().As("u")
+			cte1 := Select(u.USER_ID, u.EMAIL).From(u).Where(u.USER_ID.LtInt(5)).CTE("cte1")
+			cte2 := Select(cte1.Get("user_id"), cte1.Get("email")).From(cte1).CTE("cte2")
+			tt.q = With(cte1, cte2).Select(cte2.Get("user_id"), cte2.Get("email")).From(cte2).Join(cte1, cte1.Get("user_id").(NumberField).Eq(cte2.Get("user_id").(NumberField)))
+			tt.wantQuery = "WITH cte1 AS" +
+				" (SELECT u.user_id, u.email FROM public.users AS u WHERE u.user_id < $1)" +
+				", cte2 AS (SELECT cte1.user_id, cte1.email FROM cte1)" +
+				" SELECT cte2.user_id, cte2.email FROM cte2 JOIN cte1 ON cte1.user_id = cte2.user_id"
+			tt.wantArgs = []interface{}{5}
+			return tt
+		}(),
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -219,3 +233,62 @@ func TestCTE(t *testing.T) {
 		})
 	}
 }
+
+func TestCTE_Get(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	cte := Select(u.USER_ID, u.DISPLAYNAME.As("name")).From(u).CTE("cte")
+	is.Equal([]string{"name", "user_id"}, cte.AvailableColumns())
+	is.Equal("cte.name", cte.Get("name").(StringField).String())
+	is.Equal("c.name", cte.As("c").Get("name").(StringField).String())
+	err := func() (err error) {
+		defer func() { err, _ = recover().(error) }()
+		Select(cte.Get("displayname")).From(cte).AppendSQL(&strings.Builder{}, &[]interface{}{}, nil)
+		return nil
+	}()
+	is.True(err != nil)
+	is.Equal("CTE cte has no column displayname (available columns: name, user_id)", err.Error())
+}
+
+func TestCTE_GetTypes(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	cte := Select(u.USER_ID, u.DISPLAYNAME, Fieldf("?", 1).As("one")).From(u).CTE("cte")
+	// columns are returned as fields of the same type as their source fields,
+	// through a chain of CTEs and an alias
+	chain := Select(cte.Get("user_id"), cte.Get("displayname")).From(cte).CTE("chain").As("c")
+	userID, ok := chain.Get("user_id").(NumberField)
+	is.True(ok)
+	is.Equal("c.user_id", userID.String())
+	_, ok = chain.Get("displayname").(StringField)
+	is.True(ok)
+	// columns without a typed source are returned as CustomFields
+	_, ok = cte.Get("one").(CustomField)
+	is.True(ok)
+	// columns of a recursive CTE are typed after its initial query
+	tens := RecursiveCTE("tens")
+	tens = tens.Initial(Select(u.USER_ID.As("n")).From(u)).UnionAll()
+	_, ok = tens.Get("n").(NumberField)
+	is.True(ok)
+}
+
+func TestCTE_FieldKeys(t *testing.T) {
+	is := is.New(t)
+	u := USERS().As("u")
+	// qualified fields are output under their bare name, and their full name
+	// is kept as an alias for it
+	cte1 := Select(u.USER_ID).From(u).CTE("cte1")
+	cte2 := Select(cte1["user_id"]).From(cte1).CTE("cte2")
+	is.Equal([]string{"user_id"}, cte2.AvailableColumns())
+	is.Equal("cte2.user_id", cte2["user_id"].String())
+	is.Equal("cte2.user_id", cte2["cte1.user_id"].String())
+	is.Equal("c.user_id", cte2.As("c")["cte1.user_id"].String())
+	// an explicit column list names the columns, and the fields at the same
+	// positions resolve to them
+	cte := Select(u.USER_ID, u.EMAIL).From(u).CTE("cte", "id", "mail")
+	is.Equal([]string{"id", "mail"}, cte.AvailableColumns())
+	is.Equal("cte.id", cte.Get("id").(NumberField).String())
+	is.Equal("cte.id", cte.Get("user_id").(NumberField).String())
+	is.Equal("cte.mail", cte.Get("email").(StringField).String())
+	is.Equal("c.mail", cte.As("c").Get("email").(StringField).String())
+}
@@ -10,11 +10,16 @@ type CustomField struct {
 	Values       []interface{}
 	IsDesc       *bool
 	IsNullsFirst *bool
+	// err is set by Fieldf if the Format looks unsafe
+	err error
 }
 
 // AppendSQLExclude marshals the CustomField into an SQL query and args as
 // described in the CustomField struct description.
 func (f CustomField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	if f.err != nil {
+		panic(f.err)
+	}
 	if f.Format == "" && len(f.Values) == 0 {
 		buf.WriteString(":blank:")
 		return
@@ -36,11 +41,15 @@ func (f CustomField) AppendSQLExclude(buf *strings.Builder, args *[]interface{},
 	}
 }
 
-// Fieldf is a CustomField constructor.
+// Fieldf is a CustomField constructor. Values must be passed in as ? arguments
+// rather than concatenated into the format: a format that contains a string
+// literal, a statement separator or a comment is rejected with an error when
+// the query is built. Use an UnsafeLiteral for trusted SQL that needs them.
 func Fieldf(format string, values ...interface{}) CustomField {
 	return CustomField{
 		Format: format,
 		Values: values,
+		err:    checkFormat(format),
 	}
 }
 
@@ -224,3 +224,57 @@ func TestCustomField_BasicTesting(t *testing.T) {
 	// String
 	is.Equal("ABC, easy as 1, 2, '2 ep 2'", f.String())
 }
+
+func TestCheckFormat(t *testing.T) {
+	type TT struct {
+		description string
+		format      string
+		wantErr     bool
+	}
+	tests := []TT{
+		{"placeholders", "? BETWEEN ? AND ?", false},
+		{"quoted identifier", `"a;b" = ?`, false},
+		{"escaped quoted identifier", `"a""b" = ?`, false},
+		{"positional parameter", "? = $1", false},
+		{"string literal", "to_char(?, 'YYYY-MM-DD')", true},
+		{"concatenated value", "name = 'x' OR '1'='1'", true},
+		{"escape string", `? = E'it\'s'`, true},
+		{"dollar quoted string", "? = $$a$$", true},
+		{"tagged dollar quoted string", "? = $tag$a$tag$", true},
+		{"statement separator", "? = 1; DROP TABLE users", true},
+		{"line comment", "? = 1 -- and", true},
+		{"block comment", "? /* = */", true},
+		{"unterminated quoted identifier", `"a = ?`, true},
+		{"comment in quoted identifier", `"a--b" = ?`, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(tt.wantErr, checkFormat(tt.format) != nil)
+		})
+	}
+}
+
+func TestFieldf_Unsafe(t *testing.T) {
+	is := is.New(t)
+	u := USERS()
+	name := "bob'; DROP TABLE users; --"
+	_, args := Update(u).Set(u.DISPLAYNAME.Set(Fieldf("'" + name + "'"))).ToSQL()
+	is.Equal(1, len(args))
+	_, ok := args[0].(error)
+	is.True(ok)
+	_, args = Update(u).Where(Predicatef("displayname = '" + name + "'")).ToSQL()
+	is.Equal(1, len(args))
+	_, ok = args[0].(error)
+	is.True(ok)
+	// A value concatenated between the quotes of a string literal is rejected
+	// even if it does not contain a statement separator or a comment
+	_, args = Update(u).Where(Predicatef("displayname = '" + "x' OR '1'='1" + "'")).ToSQL()
+	is.Equal(1, len(args))
+	_, ok = args[0].(error)
+	is.True(ok)
+	// Trusted SQL can be passed in as an UnsafeLiteral instead
+	_, args = Update(u).Set(u.DISPLAYNAME.Set(Fieldf("COALESCE(?, ?)", UnsafeLiteral("'a;b'"), name))).ToSQL()
+	is.Equal([]interface{}{name}, args)
+}
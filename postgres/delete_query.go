@@ -27,9 +27,12 @@ type DeleteQuery struct {
 	// RETURNING
 	ReturningFields Fields
 	// DB
-	DB          DB
-	RowMapper   func(*Row)
-	Accumulator func()
+	DB               DB
+	PredicateGuard   *PredicateGuard
+	RetryPolicy      *RetryPolicy
+	ConstraintErrors ConstraintErrors
+	RowMapper        func(*Row)
+	Accumulator      func()
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -56,12 +59,14 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	if q.FromTable == nil {
 		buf.WriteString("NULL")
 	} else {
+		checkWritable(q.FromTable, "DELETE FROM")
 		switch v := q.FromTable.(type) {
 		case Query:
 			buf.WriteString("(")
 			v.NestThis().AppendSQL(buf, args, nil)
 			buf.WriteString(")")
 		default:
+			appendOnly(buf, q.FromTable)
 			q.FromTable.AppendSQL(buf, args, nil)
 		}
 		alias := q.FromTable.GetAlias()
@@ -79,7 +84,8 @@ func (q DeleteQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 			v.NestThis().AppendSQL(buf, args, nil)
 			buf.WriteString(")")
 		default:
-			q.FromTable.AppendSQL(buf, args, nil)
+			appendOnly(buf, q.UsingTable)
+			q.UsingTable.AppendSQL(buf, args, nil)
 		}
 		alias := q.UsingTable.GetAlias()
 		if alias != "" {
@@ -255,6 +261,27 @@ func (q DeleteQuery) ReturningRowx(mapper func(*Row)) DeleteQuery {
 	return q
 }
 
+// MapConstraintErrors sets the errors to be returned by the DeleteQuery in place
+// of database errors that violate the named constraints.
+func (q DeleteQuery) MapConstraintErrors(errs ConstraintErrors) DeleteQuery {
+	q.ConstraintErrors = errs
+	return q
+}
+
+// Guard sets the PredicateGuard that the DeleteQuery is checked (and possibly
+// amended) against when it is executed.
+func (q DeleteQuery) Guard(guard PredicateGuard) DeleteQuery {
+	q.PredicateGuard = &guard
+	return q
+}
+
+// Retry sets the RetryPolicy that is used to run the DeleteQuery again if it fails
+// with a transient error.
+func (q DeleteQuery) Retry(policy RetryPolicy) DeleteQuery {
+	q.RetryPolicy = &policy
+	return q
+}
+
 // Fetch will run DeleteQuery with the given DB. It then maps the results based
 // on the mapper function (and optionally runs the accumulator function).
 func (q DeleteQuery) Fetch(db DB) (err error) {
@@ -277,6 +304,7 @@ func (q DeleteQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	var rowcount int
 	defer func() {
 		if r := recover(); r != nil {
@@ -304,6 +332,7 @@ func (q DeleteQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			logBuf.WriteString(strconv.Itoa(rowcount))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -318,6 +347,13 @@ func (q DeleteQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	r := &Row{}
 	q.RowMapper(r)
 	q.ReturningFields = r.fields
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
@@ -328,7 +364,7 @@ func (q DeleteQuery) FetchContext(ctx context.Context, db DB) (err error) {
 		r.rows, err = db.QueryContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return err
+		return mapConstraintError(err, q.ConstraintErrors, q.FromTable)
 	}
 	defer r.rows.Close()
 	if len(r.dest) == 0 {
@@ -377,7 +413,7 @@ func (q DeleteQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	if e := r.rows.Close(); e != nil {
 		return e
 	}
-	return r.rows.Err()
+	return mapConstraintError(r.rows.Err(), q.ConstraintErrors, q.FromTable)
 }
 
 // Exec will execute the DeleteQuery with the given DB. It will only compute
@@ -398,7 +434,17 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			default:
+				err = fmt.Errorf("%#v", r)
+			}
+			return
+		}
 		if q.Log == nil {
 			return
 		}
@@ -408,6 +454,7 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -420,6 +467,13 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 	}()
 	var res sql.Result
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return 0, err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
@@ -430,7 +484,7 @@ func (q DeleteQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return rowsAffected, err
+		return rowsAffected, mapConstraintError(err, q.ConstraintErrors, q.FromTable)
 	}
 	if res != nil && ErowsAffected&flag != 0 {
 		rowsAffected, err = res.RowsAffected()
@@ -0,0 +1,216 @@
+package sq
+
+import (
+	"strings"
+)
+
+// clauseKeywords are the keywords that start a new clause in the queries
+// generated by this package. Longer keywords must come before the keywords
+// that they start with.
+var clauseKeywords = []string{
+	"WITH RECURSIVE", "WITH",
+	"SELECT",
+	"FROM",
+	"LEFT JOIN", "RIGHT JOIN", "FULL JOIN", "CROSS JOIN", "JOIN",
+	"WHERE",
+	"GROUP BY",
+	"HAVING",
+	"WINDOW",
+	"ORDER BY",
+	"LIMIT",
+	"OFFSET",
+	"UNION ALL", "UNION", "INTERSECT ALL", "INTERSECT", "EXCEPT ALL", "EXCEPT",
+	"INSERT INTO",
+	"VALUES",
+	"ON CONFLICT",
+	"DO UPDATE SET", "DO NOTHING",
+	"RETURNING",
+	"UPDATE",
+	"SET",
+	"DELETE FROM",
+	"USING",
+}
+
+// ClauseDiff is a clause that differs between two versions of a query. Old is
+// empty if the clause was added, New is empty if the clause was removed.
+type ClauseDiff struct {
+	Keyword string
+	Old     string
+	New     string
+}
+
+// String returns the ClauseDiff in a unified diff-like format i.e. the old
+// clause prefixed by '-' followed by the new clause prefixed by '+'.
+func (d ClauseDiff) String() string {
+	var lines []string
+	if d.Old != "" {
+		lines = append(lines, "-"+d.Old)
+	}
+	if d.New != "" {
+		lines = append(lines, "+"+d.New)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff renders both queries with their args interpolated and compares them
+// clause by clause. It returns the clauses that differ, in query order, or
+// nil if the queries are the same. Use it to review how a refactor of a query
+// builder (or an upgrade of this package) changes the SQL that is generated.
+// The interpolated SQL is for display purposes only.
+func Diff(oldQuery, newQuery Query) (diffs []ClauseDiff, err error) {
+	oldSQL, err := renderForDiff(oldQuery)
+	if err != nil {
+		return nil, err
+	}
+	newSQL, err := renderForDiff(newQuery)
+	if err != nil {
+		return nil, err
+	}
+	return DiffSQL(oldSQL, newSQL), nil
+}
+
+// renderForDiff renders the query with its args interpolated. The query is
+// built by buildQuery the way Fetch and Exec build it, so a query with a
+// mapper is compared with the fields of its mapper.
+func renderForDiff(q Query) (string, error) {
+	query, args, err := buildQuery(q)
+	if err != nil {
+		return "", err
+	}
+	return dollarInterpolate(query, args...), nil
+}
+
+// DiffSQL compares two query strings clause by clause, like Diff. It can be
+// used to compare queries that were rendered elsewhere, such as the queries
+// logged by two different versions of an application.
+func DiffSQL(oldSQL, newSQL string) []ClauseDiff {
+	oldClauses, newClauses := splitClauses(oldSQL), splitClauses(newSQL)
+	// lcs[i][j] is the length of the longest common subsequence of
+	// oldClauses[i:] and newClauses[j:]
+	lcs := make([][]int, len(oldClauses)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newClauses)+1)
+	}
+	for i := len(oldClauses) - 1; i >= 0; i-- {
+		for j := len(newClauses) - 1; j >= 0; j-- {
+			switch {
+			case oldClauses[i] == newClauses[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var diffs []ClauseDiff
+	var removed, added []string
+	flush := func() {
+		diffs = append(diffs, pairClauses(removed, added)...)
+		removed, added = removed[:0], added[:0]
+	}
+	i, j := 0, 0
+	for i < len(oldClauses) || j < len(newClauses) {
+		switch {
+		case i < len(oldClauses) && j < len(newClauses) && oldClauses[i] == newClauses[j]:
+			flush()
+			i++
+			j++
+		case j >= len(newClauses) || (i < len(oldClauses) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, oldClauses[i])
+			i++
+		default:
+			added = append(added, newClauses[j])
+			j++
+		}
+	}
+	flush()
+	return diffs
+}
+
+// pairClauses pairs up the removed and added clauses between two unchanged
+// clauses by their keyword, so that a modified clause is reported as a single
+// ClauseDiff rather than as a removal and an addition.
+func pairClauses(removed, added []string) []ClauseDiff {
+	var diffs []ClauseDiff
+	j := 0
+	for _, oldClause := range removed {
+		keyword := clauseKeyword(oldClause)
+		k := j
+		for k < len(added) && clauseKeyword(added[k]) != keyword {
+			k++
+		}
+		if k == len(added) {
+			diffs = append(diffs, ClauseDiff{Keyword: keyword, Old: oldClause})
+			continue
+		}
+		for ; j < k; j++ {
+			diffs = append(diffs, ClauseDiff{Keyword: clauseKeyword(added[j]), New: added[j]})
+		}
+		diffs = append(diffs, ClauseDiff{Keyword: keyword, Old: oldClause, New: added[k]})
+		j = k + 1
+	}
+	for ; j < len(added); j++ {
+		diffs = append(diffs, ClauseDiff{Keyword: clauseKeyword(added[j]), New: added[j]})
+	}
+	return diffs
+}
+
+// clauseKeyword returns the keyword that the clause starts with.
+func clauseKeyword(clause string) string {
+	for _, keyword := range clauseKeywords {
+		if clause == keyword || strings.HasPrefix(clause, keyword+" ") {
+			return keyword
+		}
+	}
+	return ""
+}
+
+// splitClauses splits the query into its top level clauses. Keywords inside
+// brackets (e.g. subqueries) or quotes do not start a new clause.
+func splitClauses(query string) []string {
+	var clauses []string
+	var depth int
+	var quote byte
+	start := 0
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '\'' || c == '"':
+			quote = c
+			continue
+		case c == '(':
+			depth++
+			continue
+		case c == ')':
+			depth--
+			continue
+		}
+		if depth != 0 || (i > 0 && query[i-1] != ' ') {
+			continue
+		}
+		for _, keyword := range clauseKeywords {
+			if !strings.HasPrefix(query[i:], keyword) {
+				continue
+			}
+			if end := i + len(keyword); end < len(query) && query[end] != ' ' {
+				continue
+			}
+			if clause := strings.TrimSpace(query[start:i]); clause != "" {
+				clauses = append(clauses, clause)
+			}
+			start = i
+			i += len(keyword) - 1
+			break
+		}
+	}
+	if clause := strings.TrimSpace(query[start:]); clause != "" {
+		clauses = append(clauses, clause)
+	}
+	return clauses
+}
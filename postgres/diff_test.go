@@ -0,0 +1,105 @@
+package sq
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSplitClauses(t *testing.T) {
+	is := is.New(t)
+	is.Equal([]string{
+		"WITH cte AS (SELECT 1 FROM t WHERE x)",
+		"SELECT u.email",
+		"FROM public.users AS u",
+		"LEFT JOIN s ON s.user_id = u.user_id",
+		"WHERE u.name = 'WHERE x' AND u.id IN (SELECT id FROM t)",
+		"ORDER BY u.email",
+	}, splitClauses("WITH cte AS (SELECT 1 FROM t WHERE x) SELECT u.email FROM public.users AS u"+
+		" LEFT JOIN s ON s.user_id = u.user_id WHERE u.name = 'WHERE x' AND u.id IN (SELECT id FROM t) ORDER BY u.email"))
+	is.Equal([]string{
+		"INSERT INTO t (a)",
+		"VALUES ($1)",
+		"ON CONFLICT (a)",
+		"DO UPDATE SET a = EXCLUDED.a",
+		"RETURNING a",
+	}, splitClauses("INSERT INTO t (a) VALUES ($1) ON CONFLICT (a) DO UPDATE SET a = EXCLUDED.a RETURNING a"))
+}
+
+func TestDiff(t *testing.T) {
+	type TT struct {
+		description string
+		oldQuery    Query
+		newQuery    Query
+		wantDiffs   []ClauseDiff
+	}
+	u, s := USERS().As("u"), SESSIONS().As("s")
+	tests := []TT{
+		{
+			"same query",
+			Select(u.EMAIL).From(u).Where(u.USER_ID.EqInt(1)),
+			Select(u.EMAIL).From(u).Where(u.USER_ID.EqInt(1)),
+			nil,
+		},
+		{
+			"changed arg",
+			Select(u.EMAIL).From(u).Where(u.USER_ID.EqInt(1)).Limit(10),
+			Select(u.EMAIL).From(u).Where(u.USER_ID.EqInt(2)).Limit(10),
+			[]ClauseDiff{
+				{Keyword: "WHERE", Old: "WHERE u.user_id = 1", New: "WHERE u.user_id = 2"},
+			},
+		},
+		{
+			"added and removed clauses",
+			Select(u.EMAIL).From(u).Where(u.USER_ID.EqInt(1)).OrderBy(u.EMAIL),
+			Select(u.EMAIL).From(u).Join(s, s.USER_ID.Eq(u.USER_ID)).Where(u.USER_ID.EqInt(1)),
+			[]ClauseDiff{
+				{Keyword: "JOIN", New: "JOIN public.sessions AS s ON s.user_id = u.user_id"},
+				{Keyword: "ORDER BY", Old: "ORDER BY u.email"},
+			},
+		},
+		{
+			"mapper fields",
+			From(u).Where(u.USER_ID.EqInt(1)).Selectx(func(row *Row) { row.String(u.EMAIL) }, nil),
+			From(u).Where(u.USER_ID.EqInt(1)).Selectx(func(row *Row) { row.String(u.DISPLAYNAME) }, nil),
+			[]ClauseDiff{
+				{Keyword: "SELECT", Old: "SELECT u.email", New: "SELECT u.displayname"},
+			},
+		},
+		{
+			"changed clause next to added clause",
+			Update(u).Set(u.EMAIL.SetString("a")).Where(u.USER_ID.EqInt(1)),
+			Update(u).Set(u.EMAIL.SetString("a")).From(s).Where(u.USER_ID.Eq(s.USER_ID)).Returning(u.EMAIL),
+			[]ClauseDiff{
+				{Keyword: "FROM", New: "FROM public.sessions AS s"},
+				{Keyword: "WHERE", Old: "WHERE u.user_id = 1", New: "WHERE u.user_id = s.user_id"},
+				{Keyword: "RETURNING", New: "RETURNING u.email"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			diffs, err := Diff(tt.oldQuery, tt.newQuery)
+			is.NoErr(err)
+			is.Equal(tt.wantDiffs, diffs)
+		})
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	is := is.New(t)
+	u := USERS()
+	errBuild := errors.New("cannot build query")
+	_, err := Diff(Select(u.EMAIL).From(u), Select(Fieldf("?", CustomField{err: errBuild})).From(u))
+	is.Equal(errBuild, err)
+}
+
+func TestClauseDiff_String(t *testing.T) {
+	is := is.New(t)
+	is.Equal("-WHERE a = 1\n+WHERE a = 2", ClauseDiff{Keyword: "WHERE", Old: "WHERE a = 1", New: "WHERE a = 2"}.String())
+	is.Equal("+LIMIT 5", ClauseDiff{Keyword: "LIMIT", New: "LIMIT 5"}.String())
+}
@@ -0,0 +1,119 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Estimate is the query planner's estimate for running a query, as reported
+// by EXPLAIN.
+type Estimate struct {
+	Cost float64
+	Rows float64
+}
+
+// Budget is the maximum Estimate that a query may have before it is refused.
+// Zero fields are not checked.
+type Budget struct {
+	MaxCost float64
+	MaxRows float64
+}
+
+// BudgetError is returned when the Estimate of a query exceeds its Budget.
+type BudgetError struct {
+	Budget   Budget
+	Estimate Estimate
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf(
+		"query estimate (cost=%g rows=%g) exceeds budget (cost=%g rows=%g)",
+		e.Estimate.Cost, e.Estimate.Rows, e.Budget.MaxCost, e.Budget.MaxRows,
+	)
+}
+
+// check returns a *BudgetError if the Estimate exceeds the Budget.
+func (b Budget) check(estimate Estimate) error {
+	if (b.MaxCost > 0 && estimate.Cost > b.MaxCost) || (b.MaxRows > 0 && estimate.Rows > b.MaxRows) {
+		return &BudgetError{Budget: b, Estimate: estimate}
+	}
+	return nil
+}
+
+// Explain runs EXPLAIN on the Query with the given DB and returns the query
+// planner's Estimate. The Query is not executed.
+func Explain(db DB, q Query) (Estimate, error) {
+	return ExplainContext(nil, db, q)
+}
+
+// ExplainContext runs EXPLAIN on the Query with the given DB and context and
+// returns the query planner's Estimate. The Query is not executed.
+func ExplainContext(ctx context.Context, db DB, q Query) (Estimate, error) {
+	if db == nil {
+		return Estimate{}, errors.New("DB cannot be nil")
+	}
+	query, args, err := buildQuery(q)
+	if err != nil {
+		return Estimate{}, err
+	}
+	return explain(ctx, db, query, args)
+}
+
+func explain(ctx context.Context, db DB, query string, args []interface{}) (estimate Estimate, err error) {
+	query = "EXPLAIN (FORMAT JSON) " + query
+	var rows *sql.Rows
+	if ctx == nil {
+		rows, err = db.Query(query, args...)
+	} else {
+		rows, err = db.QueryContext(ctx, query, args...)
+	}
+	if err != nil {
+		return estimate, err
+	}
+	defer rows.Close()
+	var plan []byte
+	if rows.Next() {
+		err = rows.Scan(&plan)
+		if err != nil {
+			return estimate, err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return estimate, err
+	}
+	return parseExplain(plan)
+}
+
+// parseExplain extracts the Estimate from the output of EXPLAIN (FORMAT JSON).
+func parseExplain(plan []byte) (Estimate, error) {
+	var explained []struct {
+		Plan struct {
+			TotalCost float64 `json:"Total Cost"`
+			PlanRows  float64 `json:"Plan Rows"`
+		}
+	}
+	err := json.Unmarshal(plan, &explained)
+	if err != nil {
+		return Estimate{}, fmt.Errorf("could not parse EXPLAIN output: %w", err)
+	}
+	if len(explained) == 0 {
+		return Estimate{}, errors.New("EXPLAIN returned no plan")
+	}
+	return Estimate{
+		Cost: explained[0].Plan.TotalCost,
+		Rows: explained[0].Plan.PlanRows,
+	}, nil
+}
+
+// checkBudget runs EXPLAIN on the query and returns a *BudgetError if its
+// Estimate exceeds the Budget.
+func checkBudget(ctx context.Context, db DB, budget Budget, query string, args []interface{}) error {
+	estimate, err := explain(ctx, db, query, args)
+	if err != nil {
+		return err
+	}
+	return budget.check(estimate)
+}
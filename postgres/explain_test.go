@@ -0,0 +1,101 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseExplain(t *testing.T) {
+	t.Run("plan", func(t *testing.T) {
+		is := is.New(t)
+		plan := `[{"Plan": {"Node Type": "Seq Scan", "Relation Name": "users", "Startup Cost": 0.00, "Total Cost": 22.70, "Plan Rows": 1270, "Plan Width": 68}}]`
+		estimate, err := parseExplain([]byte(plan))
+		is.NoErr(err)
+		is.Equal(Estimate{Cost: 22.70, Rows: 1270}, estimate)
+	})
+	t.Run("no plan", func(t *testing.T) {
+		is := is.New(t)
+		_, err := parseExplain([]byte(`[]`))
+		is.True(err != nil)
+	})
+	t.Run("invalid output", func(t *testing.T) {
+		is := is.New(t)
+		_, err := parseExplain([]byte(`Seq Scan on users`))
+		is.True(err != nil)
+	})
+}
+
+func TestExplain_BuildError(t *testing.T) {
+	is := is.New(t)
+	dbErr := errors.New("database reached")
+	u := USERS()
+	// a query that fails to build is not sent to the database
+	_, err := Explain(errDB{dbErr}, Select(Fieldf("1; DROP TABLE users")).From(u))
+	is.True(err != nil && err != dbErr)
+	view := &TableInfo{Schema: "public", Name: "user_stats", ReadOnly: true}
+	_, err = ExplainContext(context.Background(), errDB{dbErr}, InsertInto(view).Columns(FieldLiteral("a")).Values(1))
+	is.Equal(ReadOnlyError{View: "user_stats", Statement: "INSERT INTO"}, err)
+	_, err = Explain(errDB{dbErr}, Select(u.EMAIL).From(u))
+	is.Equal(dbErr, err)
+}
+
+func TestBudget(t *testing.T) {
+	type TT struct {
+		description string
+		budget      Budget
+		estimate    Estimate
+		wantErr     bool
+	}
+	tests := []TT{
+		{"empty budget", Budget{}, Estimate{Cost: 1e9, Rows: 1e9}, false},
+		{"within budget", Budget{MaxCost: 100, MaxRows: 100}, Estimate{Cost: 100, Rows: 10}, false},
+		{"cost exceeded", Budget{MaxCost: 100}, Estimate{Cost: 100.5, Rows: 10}, true},
+		{"rows exceeded", Budget{MaxCost: 100, MaxRows: 100}, Estimate{Cost: 10, Rows: 1000}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			err := tt.budget.check(tt.estimate)
+			is.Equal(tt.wantErr, err != nil)
+			if tt.wantErr {
+				var budgetErr *BudgetError
+				is.True(errors.As(err, &budgetErr))
+				is.Equal(tt.estimate, budgetErr.Estimate)
+			}
+		})
+	}
+}
+
+// recordDB is an errDB that records the queries it was given.
+type recordDB struct {
+	errDB
+	queries *[]string
+}
+
+func (db recordDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	*db.queries = append(*db.queries, query)
+	return db.errDB.Query(query, args...)
+}
+
+func TestSelectQuery_WithinBudget(t *testing.T) {
+	is := is.New(t)
+	u := USERS()
+	db := recordDB{errDB: errDB{errors.New("EXPLAIN failed")}, queries: &[]string{}}
+	var email string
+	err := From(u).
+		Where(u.USER_ID.EqInt(1)).
+		WithinBudget(Budget{MaxRows: 100}).
+		SelectRowx(func(row *Row) {
+			email = row.String(u.EMAIL)
+		}).
+		Fetch(db)
+	is.Equal(db.err, err)
+	// The query itself is not run if EXPLAIN fails
+	is.Equal([]string{"EXPLAIN (FORMAT JSON) SELECT users.email FROM public.users WHERE users.user_id = $1"}, *db.queries)
+	is.Equal("", email)
+}
@@ -0,0 +1,200 @@
+package sq
+
+import (
+	"bufio"
+	"context"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// CSVOptions configures how FetchCSV writes the results of a SelectQuery.
+type CSVOptions struct {
+	// Comma is the field delimiter. It defaults to ','.
+	Comma rune
+	// NoHeader omits the header row of column names.
+	NoHeader bool
+	// Null is written in place of NULL values. It defaults to an empty
+	// string.
+	Null string
+}
+
+// FetchCSV will run the SelectQuery with the given DB and write the results
+// to w as CSV, one record per row. The columns are the fields in the mapper
+// function, named after their aliases (or names if they are not aliased). The
+// mapper function is still called for every row but the accumulator function
+// is not, and the rows are written out as they are fetched instead of being
+// held in memory.
+func (q SelectQuery) FetchCSV(db DB, w io.Writer, opts CSVOptions) error {
+	q.logSkip += 1
+	return q.FetchCSVContext(nil, db, w, opts)
+}
+
+// FetchCSVContext is like FetchCSV but takes in a context.
+func (q SelectQuery) FetchCSVContext(ctx context.Context, db DB, w io.Writer, opts CSVOptions) error {
+	csvWriter := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		csvWriter.Comma = opts.Comma
+	}
+	var record []string
+	q.logSkip += 1
+	err := q.fetchExport(ctx, db, func(names []string) error {
+		if opts.NoHeader {
+			return nil
+		}
+		return csvWriter.Write(names)
+	}, func(values []interface{}) error {
+		record = record[:0]
+		for _, value := range values {
+			record = append(record, csvValue(value, opts.Null))
+		}
+		return csvWriter.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// FetchNDJSON will run the SelectQuery with the given DB and write the results
+// to w as newline delimited JSON, one object per row. The keys of each object
+// are the fields in the mapper function, named after their aliases (or names
+// if they are not aliased). Like FetchCSV, the rows are written out as they are
+// fetched.
+func (q SelectQuery) FetchNDJSON(db DB, w io.Writer) error {
+	q.logSkip += 1
+	return q.FetchNDJSONContext(nil, db, w)
+}
+
+// FetchNDJSONContext is like FetchNDJSON but takes in a context.
+func (q SelectQuery) FetchNDJSONContext(ctx context.Context, db DB, w io.Writer) error {
+	bufWriter := bufio.NewWriter(w)
+	var keys [][]byte
+	q.logSkip += 1
+	err := q.fetchExport(ctx, db, func(names []string) error {
+		keys = make([][]byte, len(names))
+		for i, name := range names {
+			b, err := json.Marshal(name)
+			if err != nil {
+				return err
+			}
+			keys[i] = b
+		}
+		return nil
+	}, func(values []interface{}) error {
+		bufWriter.WriteByte('{')
+		for i, value := range values {
+			if i > 0 {
+				bufWriter.WriteByte(',')
+			}
+			b, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("could not encode column %s: %w", keys[i], err)
+			}
+			bufWriter.Write(keys[i])
+			bufWriter.WriteByte(':')
+			bufWriter.Write(b)
+		}
+		_, err := bufWriter.WriteString("}\n")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return bufWriter.Flush()
+}
+
+// fetchExport runs the SelectQuery, calling writeHeader with the column names
+// before the first row and writeRow with the column values of every row.
+func (q SelectQuery) fetchExport(ctx context.Context, db DB, writeHeader func([]string) error, writeRow func([]interface{}) error) error {
+	if q.RowMapper == nil {
+		return fmt.Errorf("cannot export the results of a SelectQuery without a mapper")
+	}
+	var row *Row
+	var headerWritten bool
+	var values []interface{}
+	mapper := q.RowMapper
+	q.RowMapper = func(r *Row) {
+		row = r
+		mapper(r)
+	}
+	writeHeaderOnce := func() error {
+		if headerWritten {
+			return nil
+		}
+		headerWritten = true
+		names := make([]string, len(row.fields))
+		for i, field := range row.fields {
+			names[i] = getAliasOrName(field)
+		}
+		return writeHeader(names)
+	}
+	q.Accumulator = func() {
+		err := writeHeaderOnce()
+		if err != nil {
+			panic(err)
+		}
+		values = values[:0]
+		for _, dest := range row.dest {
+			value, err := exportValue(dest)
+			if err != nil {
+				panic(err)
+			}
+			values = append(values, value)
+		}
+		err = writeRow(values)
+		if err != nil {
+			panic(err)
+		}
+	}
+	q.logSkip += 1
+	err := q.FetchContext(ctx, db)
+	if err != nil {
+		return err
+	}
+	return writeHeaderOnce()
+}
+
+// exportValue returns the value that a scan destination holds, or nil if it
+// holds NULL.
+func exportValue(dest interface{}) (interface{}, error) {
+	if valuer, ok := dest.(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr {
+		return dest, nil
+	}
+	if value.IsNil() {
+		return nil, nil
+	}
+	return value.Elem().Interface(), nil
+}
+
+// csvValue formats a value returned by exportValue as a CSV field.
+func csvValue(value interface{}, null string) string {
+	switch v := value.(type) {
+	case nil:
+		return null
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
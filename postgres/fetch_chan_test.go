@@ -0,0 +1,63 @@
+package sq
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSelectQuery_FetchChan(t *testing.T) {
+	u := USERS()
+	type User struct {
+		UserID int
+		Email  string
+	}
+	mapper := func(row *Row) interface{} {
+		return User{UserID: row.Int(u.USER_ID), Email: row.String(u.EMAIL)}
+	}
+	t.Run("rows are the values returned by the mapper", func(t *testing.T) {
+		is := is.New(t)
+		db := openRowDB([]string{"user_id", "email"}, []driver.Value{int64(1), "adviser01@u.nus.edu"})
+		defer db.Close()
+		rows, errs, stop := From(u).FetchChan(context.Background(), db, 0, mapper)
+		defer stop()
+		var users []User
+		for v := range rows {
+			users = append(users, v.(User))
+		}
+		is.NoErr(<-errs)
+		is.Equal([]User{{UserID: 1, Email: "adviser01@u.nus.edu"}}, users)
+	})
+	t.Run("stop does not wait for the rows to be read", func(t *testing.T) {
+		is := is.New(t)
+		db := openRowDB([]string{"user_id", "email"}, []driver.Value{int64(1), "adviser01@u.nus.edu"})
+		defer db.Close()
+		_, errs, stop := From(u).FetchChan(nil, db, 0, mapper)
+		stop() // returns only once the goroutine has exited
+		is.Equal(context.Canceled, <-errs)
+		_, ok := <-errs
+		is.True(!ok) // the error channel is closed after the error
+	})
+	t.Run("query error", func(t *testing.T) {
+		is := is.New(t)
+		dbErr := errors.New("database reached")
+		rows, errs, stop := From(u).FetchChan(context.Background(), errDB{dbErr}, 1, mapper)
+		defer stop()
+		for range rows {
+			t.Fatal("no rows should be sent")
+		}
+		is.Equal(dbErr, <-errs)
+	})
+	t.Run("no mapper", func(t *testing.T) {
+		is := is.New(t)
+		rows, errs, stop := From(u).Select(u.EMAIL).FetchChan(nil, errDB{errors.New("database reached")}, 0, nil)
+		defer stop()
+		for range rows {
+			t.Fatal("no rows should be sent")
+		}
+		is.True(<-errs != nil)
+	})
+}
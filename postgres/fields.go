@@ -23,6 +23,39 @@ func (f FieldLiteral) GetName() string {
 	return string(f)
 }
 
+// UnsafeLiteral is trusted SQL that is plugged into the query as is. Unlike the
+// Format of Fieldf and Predicatef it is never checked for injection, so it
+// should only ever hold SQL written by the programmer. Its distinct type makes
+// such raw SQL easy to audit. An UnsafeLiteral can be used directly as a Field
+// or Predicate, or as one of the values of Fieldf and Predicatef.
+type UnsafeLiteral string
+
+// AppendSQLExclude marshals the UnsafeLiteral into a buffer.
+func (l UnsafeLiteral) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	buf.WriteString(string(l))
+}
+
+// Not implements the Predicate interface.
+func (l UnsafeLiteral) Not() Predicate {
+	return CustomPredicate{
+		Format:   "?",
+		Values:   []interface{}{l},
+		Negative: true,
+	}
+}
+
+// GetAlias implements the Field interface. It always returns an empty string
+// because UnsafeLiterals do not have aliases.
+func (l UnsafeLiteral) GetAlias() string {
+	return ""
+}
+
+// GetName implements the Field interface. It returns the UnsafeLiteral's
+// underlying string as the name.
+func (l UnsafeLiteral) GetName() string {
+	return string(l)
+}
+
 // Fields represents the "field1, field2, etc..." SQL construct.
 type Fields []Field
 
@@ -0,0 +1,194 @@
+// Package fragments provides reusable SQL fragments with named slots, for the
+// expressions and predicates that would otherwise be written ad hoc with
+// sq.Fieldf and sq.Predicatef. A Template is parsed once and can be rendered
+// into any query type by filling in its slots:
+//
+//	between := fragments.MustNew("{field} BETWEEN {start} AND {end}")
+//	sq.From(u).Where(between.Predicate(fragments.Values{
+//		"field": u.CREATED_AT,
+//		"start": start,
+//		"end":   end,
+//	}))
+package fragments
+
+import (
+	"fmt"
+	"strings"
+
+	sq "github.com/bokwoon95/go-structured-query/postgres"
+)
+
+// Values maps the slot names of a Template to the values that fill them in.
+// A value may be a literal (which becomes a query argument), a slice (which
+// becomes a list of arguments), a Field, a Predicate or a Query.
+type Values map[string]interface{}
+
+// Template is a reusable SQL fragment with named slots e.g.
+// "{field} BETWEEN {start} AND {end}". A slot name may contain letters, digits
+// and underscores. A literal opening brace is written as "{{" and a literal
+// closing brace as "}}". A question mark inside a quoted string or identifier
+// is written as is, and a literal question mark anywhere else (such as the
+// jsonb ? operator) is written as "??".
+type Template struct {
+	// format is the fragment with each slot replaced by a ? placeholder
+	format string
+	// slots are the slot names for each ? placeholder in format, in order. An
+	// empty slot name stands for a literal question mark.
+	slots []string
+}
+
+// New parses the format into a Template. Question mark ? placeholders outside
+// of quotes are not allowed, use a named slot instead (or ?? for a literal
+// question mark).
+func New(format string) (Template, error) {
+	var t Template
+	buf := &strings.Builder{}
+	var quote byte
+	var escapes bool
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if quote != 0 && c != '{' && c != '}' {
+			switch {
+			case c == '?':
+				t.slots = append(t.slots, "")
+				buf.WriteString("?")
+				continue
+			case c == '\\' && escapes && i+1 < len(format) && format[i+1] != '?':
+				buf.WriteByte(c)
+				i++
+				c = format[i]
+			case c == quote:
+				quote = 0
+			}
+			buf.WriteByte(c)
+			continue
+		}
+		switch {
+		case c == '\'' || c == '"':
+			quote = c
+			// a backslash escapes the next character only inside E'...'
+			// escape strings
+			escapes = c == '\'' && i > 0 && (format[i-1] == 'E' || format[i-1] == 'e') &&
+				(i == 1 || !isWordChar(format[i-2]))
+			buf.WriteByte(c)
+		case c == '?' && i+1 < len(format) && format[i+1] == '?':
+			t.slots = append(t.slots, "")
+			buf.WriteString("?")
+			i++
+		case c == '?':
+			return Template{}, fmt.Errorf("fragments: %q contains a ? placeholder, use a named slot instead (or ?? for a literal question mark)", format)
+		case c == '{' && i+1 < len(format) && format[i+1] == '{':
+			buf.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(format) && format[i+1] == '}':
+			buf.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexByte(format[i:], '}')
+			if end < 0 || !isSlotName(format[i+1:i+end]) {
+				buf.WriteByte(c)
+				continue
+			}
+			t.slots = append(t.slots, format[i+1:i+end])
+			buf.WriteString("?")
+			i += end
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	t.format = buf.String()
+	return t, nil
+}
+
+// MustNew is like New but panics if the format cannot be parsed. It is meant
+// for initializing package level Templates.
+func MustNew(format string) Template {
+	t, err := New(format)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// questionMark is the value that a literal question mark in the format is
+// filled in with. Postgres queries are rebound from ? to $1 placeholders, in
+// which a literal question mark is written as ??.
+const questionMark = sq.FieldLiteral("??")
+
+func isSlotName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '_' && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') && !('0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// Slots returns the slot names of the Template in the order that they appear.
+// A slot that appears more than once is listed each time.
+func (t Template) Slots() []string {
+	var slots []string
+	for _, slot := range t.slots {
+		if slot != "" {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// Field fills in the slots of the Template with the values and returns it as
+// an sq.CustomField. Like an sq.UnsafeLiteral, the format of the Template is
+// trusted SQL, so unlike the format of sq.Fieldf it may contain string
+// literals.
+func (t Template) Field(values Values) sq.CustomField {
+	return sq.CustomField{
+		Format: t.format,
+		Values: t.fill(values),
+	}
+}
+
+// Predicate fills in the slots of the Template with the values and returns it
+// as an sq.CustomPredicate. Like Field, it trusts the format of the Template.
+func (t Template) Predicate(values Values) sq.CustomPredicate {
+	return sq.CustomPredicate{
+		Format: t.format,
+		Values: t.fill(values),
+	}
+}
+
+func (t Template) fill(values Values) []interface{} {
+	filled := make([]interface{}, len(t.slots))
+	for i, slot := range t.slots {
+		if slot == "" {
+			filled[i] = questionMark
+			continue
+		}
+		value, ok := values[slot]
+		if !ok {
+			filled[i] = missingSlot(slot)
+			continue
+		}
+		if query, ok := value.(sq.Query); ok {
+			value = query.NestThis()
+		}
+		filled[i] = value
+	}
+	return filled
+}
+
+// missingSlot stands in for the value of a slot that was not filled in. It
+// panics with an error when it is rendered, in the same way that a panicking
+// ColumnMapper is reported.
+type missingSlot string
+
+func (slot missingSlot) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	panic(fmt.Errorf("fragments: no value for slot {%s}", string(slot)))
+}
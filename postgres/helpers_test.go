@@ -0,0 +1,54 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// rowDriver is a database/sql driver whose every query returns the same single
+// row.
+type rowDriver struct {
+	columns []string
+	values  []driver.Value
+}
+
+// openRowDB returns an *sql.DB whose every query returns a single row with the
+// given columns and values.
+func openRowDB(columns []string, values []driver.Value) *sql.DB {
+	return sql.OpenDB(&rowConnector{rowDriver{columns: columns, values: values}})
+}
+
+type rowConnector struct{ d rowDriver }
+
+func (c *rowConnector) Open(name string) (driver.Conn, error)            { return c.d, nil }
+func (c *rowConnector) Connect(ctx context.Context) (driver.Conn, error) { return c.d, nil }
+func (c *rowConnector) Driver() driver.Driver                            { return c }
+
+func (d rowDriver) Prepare(query string) (driver.Stmt, error) { return d, nil }
+func (d rowDriver) Close() error                              { return nil }
+func (d rowDriver) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+func (d rowDriver) NumInput() int                             { return -1 }
+func (d rowDriver) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (d rowDriver) Query(args []driver.Value) (driver.Rows, error) {
+	return &rowDriverRows{rowDriver: d}, nil
+}
+
+type rowDriverRows struct {
+	rowDriver
+	done bool
+}
+
+func (r *rowDriverRows) Columns() []string { return r.columns }
+func (r *rowDriverRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
@@ -34,10 +34,16 @@ type InsertQuery struct {
 	// RETURNING
 	ReturningFields Fields
 	// DB
-	DB           DB
-	ColumnMapper func(*Column)
-	RowMapper    func(*Row)
-	Accumulator  func()
+	DB               DB
+	PredicateGuard   *PredicateGuard
+	RetryPolicy      *RetryPolicy
+	ConstraintErrors ConstraintErrors
+	ColumnMapper     func(*Column)
+	ColumnFill       ColumnFill
+	RowMapper        func(*Row)
+	Accumulator      func()
+	// Validation
+	EnumValidation bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -64,11 +70,22 @@ func (q InsertQuery) ToSQL() (query string, args []interface{}) {
 func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params map[string]int) {
 	var excludedTableQualifiers []string
 	if q.ColumnMapper != nil {
-		col := &Column{mode: colmodeInsert}
+		col := &Column{mode: colmodeInsert, fill: q.ColumnFill}
 		q.ColumnMapper(col)
+		col.endRow()
 		q.InsertColumns = col.insertColumns
 		q.RowValues = col.rowValues
 	}
+	if q.EnumValidation {
+		for _, rowValue := range q.RowValues {
+			for i, value := range rowValue {
+				if i < len(q.InsertColumns) {
+					validateEnum(q.InsertColumns[i], value)
+				}
+			}
+		}
+		validateEnumAssignments(q.Resolution)
+	}
 	// WITH
 	if !q.nested && q.SelectQuery != nil {
 		appendCTEs(buf, args, q.CTEs, q.SelectQuery.FromTable, q.SelectQuery.JoinTables)
@@ -78,6 +95,8 @@ func (q InsertQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	if q.IntoTable == nil {
 		buf.WriteString("NULL")
 	} else {
+		checkWritable(q.IntoTable, "INSERT INTO")
+		checkNotOnly(q.IntoTable, "INSERT INTO")
 		q.IntoTable.AppendSQL(buf, args, nil)
 		name := q.IntoTable.GetName()
 		alias := q.IntoTable.GetAlias()
@@ -201,12 +220,91 @@ func (q InsertQuery) Values(values ...interface{}) InsertQuery {
 	return q
 }
 
+// Record appends a new RowValue to the InsertQuery, using the Fields of the
+// assignments as the insert columns, e.g.
+//
+//	InsertInto(u).Record(u.USER_ID.SetInt(1), u.EMAIL.SetString("bob@email.com"))
+func (q InsertQuery) Record(record ...FieldAssignment) InsertQuery {
+	return q.Records(record)
+}
+
+// Records appends a new RowValue for each record to the InsertQuery, using the
+// Fields of the assignments as the insert columns. The columns are matched by
+// name and ordered by where they first appear in the records. Every record
+// must set the same columns, unless FillMissing is used. Like Valuesx, Records
+// replaces any columns and values set by Columns and Values.
+func (q InsertQuery) Records(records ...[]FieldAssignment) InsertQuery {
+	if len(records) == 0 {
+		return q
+	}
+	var fields Fields
+	seen := make(map[string]bool)
+	for _, record := range records {
+		for _, assignment := range record {
+			if assignment.Field == nil || seen[assignment.Field.GetName()] {
+				continue
+			}
+			seen[assignment.Field.GetName()] = true
+			fields = append(fields, assignment.Field)
+		}
+	}
+	mapper := q.ColumnMapper
+	q.ColumnMapper = func(col *Column) {
+		if mapper != nil {
+			mapper(col)
+		}
+		for i, record := range records {
+			col.NextRow()
+			values := make(map[string]interface{}, len(record))
+			for _, assignment := range record {
+				if assignment.Field == nil {
+					continue
+				}
+				name := assignment.Field.GetName()
+				if _, ok := values[name]; ok {
+					panic(fmt.Errorf("record %d sets column %s twice", i, name))
+				}
+				values[name] = assignment.Value
+			}
+			for _, field := range fields {
+				value, ok := values[field.GetName()]
+				if !ok {
+					if col.fill != FillNone {
+						col.Set(field, col.fill.value())
+						continue
+					}
+					panic(fmt.Errorf("record %d has no value for column %s", i, field.GetName()))
+				}
+				col.Set(field, value)
+			}
+		}
+	}
+	return q
+}
+
 // Valuesx sets the column mapper for the InsertQuery.
 func (q InsertQuery) Valuesx(mapper func(*Column)) InsertQuery {
 	q.ColumnMapper = mapper
 	return q
 }
 
+// FillMissing allows rows in Valuesx to skip insert columns, which will be
+// filled in with either NULL or DEFAULT. Since a row that skips a column
+// cannot otherwise be told apart from the rows around it, the rows must be
+// separated by calling NextRow on the Column, and setting a column twice in
+// the same row panics.
+func (q InsertQuery) FillMissing(fill ColumnFill) InsertQuery {
+	q.ColumnFill = fill
+	return q
+}
+
+// ValidateEnums makes the InsertQuery check that every string inserted into an
+// EnumField is one of its allowed values, returning an *EnumError otherwise.
+func (q InsertQuery) ValidateEnums() InsertQuery {
+	q.EnumValidation = true
+	return q
+}
+
 // Select adds a SelectQuery to the InsertQuery.
 func (q InsertQuery) Select(selectQuery SelectQuery) InsertQuery {
 	q.SelectQuery = &selectQuery
@@ -294,6 +392,27 @@ func (q InsertQuery) ReturningRowx(mapper func(*Row)) InsertQuery {
 	return q
 }
 
+// MapConstraintErrors sets the errors to be returned by the InsertQuery in place
+// of database errors that violate the named constraints.
+func (q InsertQuery) MapConstraintErrors(errs ConstraintErrors) InsertQuery {
+	q.ConstraintErrors = errs
+	return q
+}
+
+// Guard sets the PredicateGuard of the InsertQuery, which is applied to the
+// InsertQuery's SELECT (if any) when it is executed.
+func (q InsertQuery) Guard(guard PredicateGuard) InsertQuery {
+	q.PredicateGuard = &guard
+	return q
+}
+
+// Retry sets the RetryPolicy that is used to run the InsertQuery again if it fails
+// with a transient error.
+func (q InsertQuery) Retry(policy RetryPolicy) InsertQuery {
+	q.RetryPolicy = &policy
+	return q
+}
+
 // Fetch will run InsertQuery with the given DB. It then maps the results based
 // on the mapper function (and optionally runs the accumulator function).
 func (q InsertQuery) Fetch(db DB) (err error) {
@@ -316,6 +435,7 @@ func (q InsertQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	var rowcount int
 	defer func() {
 		if r := recover(); r != nil {
@@ -343,6 +463,7 @@ func (q InsertQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			logBuf.WriteString(strconv.Itoa(rowcount))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -357,6 +478,13 @@ func (q InsertQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	r := &Row{}
 	q.RowMapper(r)
 	q.ReturningFields = r.fields
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
@@ -367,7 +495,7 @@ func (q InsertQuery) FetchContext(ctx context.Context, db DB) (err error) {
 		r.rows, err = db.QueryContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return err
+		return mapConstraintError(err, q.ConstraintErrors, q.IntoTable)
 	}
 	defer r.rows.Close()
 	if len(r.dest) == 0 {
@@ -416,7 +544,7 @@ func (q InsertQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	if e := r.rows.Close(); e != nil {
 		return e
 	}
-	return r.rows.Err()
+	return mapConstraintError(r.rows.Err(), q.ConstraintErrors, q.IntoTable)
 }
 
 // Exec will execute the InsertQuery with the given DB. It will only compute
@@ -437,6 +565,7 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	defer func() {
 		if r := recover(); r != nil {
 			switch v := r.(type) {
@@ -456,6 +585,7 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -468,6 +598,13 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 	}()
 	var res sql.Result
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return 0, err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
@@ -478,7 +615,7 @@ func (q InsertQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return rowsAffected, err
+		return rowsAffected, mapConstraintError(err, q.ConstraintErrors, q.IntoTable)
 	}
 	if res != nil && ErowsAffected&flag != 0 {
 		rowsAffected, err = res.RowsAffected()
@@ -152,6 +152,103 @@ func TestInsertQuery_ToSQL(t *testing.T) {
 			}
 			return tt
 		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Record"
+			u := USERS().As("u")
+			tt.q = WithDefaultLog(Lverbose).
+				InsertInto(u).
+				Record(
+					u.USER_ID.SetInt(1),
+					u.DISPLAYNAME.SetString("Bob"),
+					u.EMAIL.SetString("bob@email.com"),
+					u.PASSWORD.SetString("cant_hack_me"),
+				)
+			tt.wantQuery = "INSERT INTO public.users AS u (user_id, displayname, email, password)" +
+				" VALUES ($1, $2, $3, $4)"
+			tt.wantArgs = []interface{}{1, "Bob", "bob@email.com", "cant_hack_me"}
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Records"
+			u := USERS().As("u")
+			tt.q = WithDefaultLog(Lverbose).
+				InsertInto(u).
+				Records(
+					[]FieldAssignment{u.DISPLAYNAME.SetString("Bob"), u.EMAIL.SetString("bob@email.com")},
+					[]FieldAssignment{u.EMAIL.SetString("alice@email.com"), u.DISPLAYNAME.SetString("Alice")},
+				).
+				Record(u.DISPLAYNAME.SetString("Tom"), u.EMAIL.SetString("tom@email.com"))
+			tt.wantQuery = "INSERT INTO public.users AS u (displayname, email)" +
+				" VALUES ($1, $2), ($3, $4), ($5, $6)"
+			tt.wantArgs = []interface{}{"Bob", "bob@email.com", "Alice", "alice@email.com", "Tom", "tom@email.com"}
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Records missing value"
+			u := USERS().As("u")
+			tt.q = WithDefaultLog(Lverbose).
+				InsertInto(u).
+				Records(
+					[]FieldAssignment{u.DISPLAYNAME.SetString("Bob"), u.EMAIL.SetString("bob@email.com")},
+					[]FieldAssignment{u.DISPLAYNAME.SetString("Alice")},
+				)
+			tt.wantQuery = ""
+			tt.wantArgs = []interface{}{errors.New("record 1 has no value for column email")}
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Valuesx FillMissing"
+			type OptionalUser struct {
+				Displayname string
+				Email       *string
+				Password    *string
+			}
+			email, password := "bob@email.com", "alice alice"
+			users := []OptionalUser{
+				{Displayname: "Bob", Email: &email},
+				{Displayname: "Alice", Password: &password},
+			}
+			u := USERS().As("u")
+			tt.q = WithDefaultLog(Lverbose).
+				InsertInto(u).
+				Valuesx(func(col *Column) {
+					for _, user := range users {
+						col.NextRow()
+						col.SetString(u.DISPLAYNAME, user.Displayname)
+						if user.Password != nil {
+							col.SetString(u.PASSWORD, *user.Password)
+						}
+						if user.Email != nil {
+							col.SetString(u.EMAIL, *user.Email)
+						}
+					}
+				}).
+				FillMissing(FillDefault)
+			tt.wantQuery = "INSERT INTO public.users AS u (displayname, email, password)" +
+				" VALUES ($1, $2, DEFAULT), ($3, DEFAULT, $4)"
+			tt.wantArgs = []interface{}{"Bob", email, "Alice", password}
+			return tt
+		}(),
+		func() TT {
+			var tt TT
+			tt.description = "Records FillMissing"
+			u := USERS().As("u")
+			tt.q = WithDefaultLog(Lverbose).
+				InsertInto(u).
+				Records(
+					[]FieldAssignment{u.USER_ID.SetInt(1), u.DISPLAYNAME.SetString("Bob")},
+					[]FieldAssignment{u.DISPLAYNAME.SetString("Alice"), u.EMAIL.SetString("alice@email.com")},
+				).
+				FillMissing(FillNull)
+			tt.wantQuery = "INSERT INTO public.users AS u (user_id, displayname, email)" +
+				" VALUES ($1, $2, NULL), (NULL, $3, $4)"
+			tt.wantArgs = []interface{}{1, "Bob", "Alice", "alice@email.com"}
+			return tt
+		}(),
 		func() TT {
 			var tt TT
 			tt.description = "ToSQL ColumnMapper panic translates to empty query and panicked value in args"
@@ -0,0 +1,187 @@
+package sq
+
+// The accessors in this file let code that receives an already-built query
+// (middleware, authorization filters, tenant scoping checks) inspect what the
+// query selects, which tables it touches and which predicates it filters on,
+// without having to marshal the query into SQL first.
+
+// conjuncts flattens a VariadicPredicate into the list of predicates that must
+// all hold for it to hold, descending into nested non-negated ANDs. An OR is
+// kept whole as a single predicate since none of its children must hold on
+// their own.
+func conjuncts(p VariadicPredicate) []Predicate {
+	if p.Negative {
+		if len(p.Predicates) == 0 {
+			return nil
+		}
+		return []Predicate{p}
+	}
+	if p.Operator != "" && p.Operator != PredicateAnd && len(p.Predicates) > 1 {
+		return []Predicate{p}
+	}
+	var predicates []Predicate
+	for _, predicate := range p.Predicates {
+		if v, ok := predicate.(VariadicPredicate); ok {
+			predicates = append(predicates, conjuncts(v)...)
+			continue
+		}
+		if predicate != nil {
+			predicates = append(predicates, predicate)
+		}
+	}
+	return predicates
+}
+
+// mapperFields returns the fields that the mapper selects, which is how Fetch
+// builds the SELECT (or RETURNING) clause of a query with a mapper.
+func mapperFields(mapper func(*Row)) Fields {
+	r := &Row{}
+	mapper(r)
+	return r.fields
+}
+
+// appendTables appends the non-nil tables and the join tables to tables.
+func appendTables(tables []Table, joinTables JoinTables, ts ...Table) []Table {
+	for _, t := range ts {
+		if t != nil {
+			tables = append(tables, t)
+		}
+	}
+	for _, joinTable := range joinTables {
+		if joinTable.Table != nil {
+			tables = append(tables, joinTable.Table)
+		}
+	}
+	return tables
+}
+
+// SelectedFields returns the fields in the SELECT clause of the SelectQuery. If
+// the SelectQuery has a mapper, they are the fields that the mapper selects.
+func (q SelectQuery) SelectedFields() Fields {
+	q = q.withMapperFields()
+	return append(Fields(nil), q.SelectFields...)
+}
+
+// withMapperFields returns a copy of the SelectQuery whose SELECT clause holds
+// the fields of its mapper (if it has one), as it would be when fetched.
+func (q SelectQuery) withMapperFields() SelectQuery {
+	if q.RowMapper != nil {
+		q.SelectFields = mapperFields(q.RowMapper)
+	}
+	return q
+}
+
+// Tables returns the tables in the FROM and JOIN clauses of the SelectQuery.
+// Tables referenced only inside subqueries or CTEs are not included.
+func (q SelectQuery) Tables() []Table {
+	return appendTables(nil, q.JoinTables, q.FromTable)
+}
+
+// Predicates returns the predicates of the WHERE clause of the SelectQuery
+// that must all hold for a row to be selected, i.e. the WHERE clause split on
+// its top level ANDs.
+func (q SelectQuery) Predicates() []Predicate {
+	return conjuncts(q.WherePredicate)
+}
+
+// SelectedFields returns the fields in the RETURNING clause of the InsertQuery. If
+// the InsertQuery has a mapper, they are the fields that the mapper selects.
+func (q InsertQuery) SelectedFields() Fields {
+	q = q.withMapperFields()
+	return append(Fields(nil), q.ReturningFields...)
+}
+
+// withMapperFields returns a copy of the InsertQuery whose RETURNING clause holds
+// the fields of its mapper (if it has one), as it would be when fetched.
+func (q InsertQuery) withMapperFields() InsertQuery {
+	if q.RowMapper != nil {
+		q.ReturningFields = mapperFields(q.RowMapper)
+	}
+	return q
+}
+
+// Tables returns the table being inserted into, followed by the tables of the
+// InsertQuery's SELECT (if any).
+func (q InsertQuery) Tables() []Table {
+	var tables []Table
+	if q.IntoTable != nil {
+		tables = append(tables, q.IntoTable)
+	}
+	if q.SelectQuery != nil {
+		tables = append(tables, q.SelectQuery.Tables()...)
+	}
+	return tables
+}
+
+// Predicates returns the predicates of the WHERE clause of the InsertQuery's
+// SELECT (if any) that must all hold for a row to be inserted.
+func (q InsertQuery) Predicates() []Predicate {
+	if q.SelectQuery == nil {
+		return nil
+	}
+	return q.SelectQuery.Predicates()
+}
+
+// SelectedFields returns the fields in the RETURNING clause of the UpdateQuery. If
+// the UpdateQuery has a mapper, they are the fields that the mapper selects.
+func (q UpdateQuery) SelectedFields() Fields {
+	q = q.withMapperFields()
+	return append(Fields(nil), q.ReturningFields...)
+}
+
+// withMapperFields returns a copy of the UpdateQuery whose RETURNING clause holds
+// the fields of its mapper (if it has one), as it would be when fetched.
+func (q UpdateQuery) withMapperFields() UpdateQuery {
+	if q.RowMapper != nil {
+		q.ReturningFields = mapperFields(q.RowMapper)
+	}
+	return q
+}
+
+// Tables returns the table being updated, followed by the tables in the FROM
+// and JOIN clauses of the UpdateQuery.
+func (q UpdateQuery) Tables() []Table {
+	var tables []Table
+	if q.UpdateTable != nil {
+		tables = append(tables, q.UpdateTable)
+	}
+	return appendTables(tables, q.JoinTables, q.FromTable)
+}
+
+// Predicates returns the predicates of the WHERE clause of the UpdateQuery
+// that must all hold for a row to be updated.
+func (q UpdateQuery) Predicates() []Predicate {
+	return conjuncts(q.WherePredicate)
+}
+
+// SelectedFields returns the fields in the RETURNING clause of the DeleteQuery. If
+// the DeleteQuery has a mapper, they are the fields that the mapper selects.
+func (q DeleteQuery) SelectedFields() Fields {
+	q = q.withMapperFields()
+	return append(Fields(nil), q.ReturningFields...)
+}
+
+// withMapperFields returns a copy of the DeleteQuery whose RETURNING clause holds
+// the fields of its mapper (if it has one), as it would be when fetched.
+func (q DeleteQuery) withMapperFields() DeleteQuery {
+	if q.RowMapper != nil {
+		q.ReturningFields = mapperFields(q.RowMapper)
+	}
+	return q
+}
+
+// Tables returns the table being deleted from, followed by the tables in the
+// USING and JOIN clauses of the DeleteQuery.
+func (q DeleteQuery) Tables() []Table {
+	var tables []Table
+	if q.FromTable != nil {
+		tables = append(tables, q.FromTable)
+	}
+	return appendTables(tables, q.JoinTables, q.UsingTable)
+}
+
+// Predicates returns the predicates of the WHERE clause of the DeleteQuery
+// that must all hold for a row to be deleted.
+func (q DeleteQuery) Predicates() []Predicate {
+	return conjuncts(q.WherePredicate)
+}
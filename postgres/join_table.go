@@ -90,6 +90,7 @@ func (join JoinTable) AppendSQL(buf *strings.Builder, args *[]interface{}, param
 		v.NestThis().AppendSQL(buf, args, nil)
 		buf.WriteString(")")
 	default:
+		appendOnly(buf, join.Table)
 		join.Table.AppendSQL(buf, args, nil)
 	}
 	if join.Table != nil {
@@ -0,0 +1,285 @@
+package sq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// AnalyzeQuery represents an ANALYZE query.
+type AnalyzeQuery struct {
+	nested bool
+	// ANALYZE
+	Tables    []BaseTable
+	IsVerbose bool
+	// DB
+	DB DB
+	// Logging
+	Log     Logger
+	LogFlag LogFlag
+	logSkip int
+}
+
+// ToSQL marshals the AnalyzeQuery into a query string and args slice.
+func (q AnalyzeQuery) ToSQL() (string, []interface{}) {
+	q.logSkip += 1
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args, nil)
+	return buf.String(), args
+}
+
+// AppendSQL marshals the AnalyzeQuery into a buffer and args slice.
+func (q AnalyzeQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params map[string]int) {
+	buf.WriteString("ANALYZE")
+	if q.IsVerbose {
+		buf.WriteString(" VERBOSE")
+	}
+	appendMaintenanceTables(buf, args, "ANALYZE", q.Tables)
+	if !q.nested {
+		logUtility(buf.String(), q.Log, q.LogFlag, q.logSkip+1)
+	}
+}
+
+// NestThis indicates to the AnalyzeQuery that it is nested.
+func (q AnalyzeQuery) NestThis() Query {
+	q.nested = true
+	return q
+}
+
+// Analyze creates a new AnalyzeQuery. If no tables are given, every table in
+// the database is analyzed.
+func Analyze(tables ...BaseTable) AnalyzeQuery {
+	return AnalyzeQuery{
+		Tables: tables,
+	}
+}
+
+// Verbose makes the AnalyzeQuery report its progress as it runs.
+func (q AnalyzeQuery) Verbose() AnalyzeQuery {
+	q.IsVerbose = true
+	return q
+}
+
+// Exec will execute the AnalyzeQuery with the given DB.
+func (q AnalyzeQuery) Exec(db DB) (err error) {
+	q.logSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+// ExecContext will execute the AnalyzeQuery with the given DB and context.
+func (q AnalyzeQuery) ExecContext(ctx context.Context, db DB) (err error) {
+	if db == nil {
+		if q.DB == nil {
+			return errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	q.logSkip += 2
+	return execUtility(ctx, db, q, q.Log, q.LogFlag, q.logSkip, "Analyzed", nil)
+}
+
+// VacuumQuery represents a VACUUM query. VACUUM cannot be run inside a
+// transaction, so the DB must not be an *sql.Tx.
+type VacuumQuery struct {
+	nested bool
+	// VACUUM
+	Tables    []BaseTable
+	IsFull    bool
+	IsFreeze  bool
+	IsVerbose bool
+	IsAnalyze bool
+	// DB
+	DB DB
+	// Logging
+	Log     Logger
+	LogFlag LogFlag
+	logSkip int
+}
+
+// ToSQL marshals the VacuumQuery into a query string and args slice.
+func (q VacuumQuery) ToSQL() (string, []interface{}) {
+	q.logSkip += 1
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args, nil)
+	return buf.String(), args
+}
+
+// AppendSQL marshals the VacuumQuery into a buffer and args slice.
+func (q VacuumQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params map[string]int) {
+	buf.WriteString("VACUUM")
+	var options []string
+	if q.IsFull {
+		options = append(options, "FULL")
+	}
+	if q.IsFreeze {
+		options = append(options, "FREEZE")
+	}
+	if q.IsVerbose {
+		options = append(options, "VERBOSE")
+	}
+	if q.IsAnalyze {
+		options = append(options, "ANALYZE")
+	}
+	if len(options) > 0 {
+		buf.WriteString(" (" + strings.Join(options, ", ") + ")")
+	}
+	appendMaintenanceTables(buf, args, "VACUUM", q.Tables)
+	if !q.nested {
+		logUtility(buf.String(), q.Log, q.LogFlag, q.logSkip+1)
+	}
+}
+
+// NestThis indicates to the VacuumQuery that it is nested.
+func (q VacuumQuery) NestThis() Query {
+	q.nested = true
+	return q
+}
+
+// Vacuum creates a new VacuumQuery. If no tables are given, every table in the
+// database is vacuumed.
+func Vacuum(tables ...BaseTable) VacuumQuery {
+	return VacuumQuery{
+		Tables: tables,
+	}
+}
+
+// Full makes the VacuumQuery rewrite the tables to reclaim their unused space.
+// It locks the tables exclusively while it runs.
+func (q VacuumQuery) Full() VacuumQuery {
+	q.IsFull = true
+	return q
+}
+
+// Freeze makes the VacuumQuery freeze every row of the tables.
+func (q VacuumQuery) Freeze() VacuumQuery {
+	q.IsFreeze = true
+	return q
+}
+
+// Verbose makes the VacuumQuery report its progress as it runs.
+func (q VacuumQuery) Verbose() VacuumQuery {
+	q.IsVerbose = true
+	return q
+}
+
+// Analyze makes the VacuumQuery also update the query planner's statistics
+// of the tables, as ANALYZE does.
+func (q VacuumQuery) Analyze() VacuumQuery {
+	q.IsAnalyze = true
+	return q
+}
+
+// Exec will execute the VacuumQuery with the given DB.
+func (q VacuumQuery) Exec(db DB) (err error) {
+	q.logSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+// ExecContext will execute the VacuumQuery with the given DB and context.
+func (q VacuumQuery) ExecContext(ctx context.Context, db DB) (err error) {
+	if db == nil {
+		if q.DB == nil {
+			return errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	q.logSkip += 2
+	return execUtility(ctx, db, q, q.Log, q.LogFlag, q.logSkip, "Vacuumed", nil)
+}
+
+// appendMaintenanceTables writes the comma separated list of tables of a
+// maintenance statement into the buffer. Table aliases are not written. It
+// panics with an OnlyError if a table was returned by Only.
+func appendMaintenanceTables(buf *strings.Builder, args *[]interface{}, statement string, tables []BaseTable) {
+	for i, table := range tables {
+		if i == 0 {
+			buf.WriteString(" ")
+		} else {
+			buf.WriteString(", ")
+		}
+		if table == nil {
+			buf.WriteString("NULL")
+			continue
+		}
+		checkNotOnly(table, statement)
+		table.AppendSQL(buf, args, nil)
+	}
+}
+
+// logUtility logs a utility statement (a statement that is not a
+// SELECT/INSERT/UPDATE/DELETE, and so has no args) with the Logger.
+func logUtility(query string, logger Logger, flag LogFlag, logSkip int) {
+	if logger == nil {
+		return
+	}
+	logOutput := query
+	if Lstats&flag != 0 {
+		logOutput = "\n----[ Executing query ]----\n" + query
+	}
+	switch logger.(type) {
+	case *log.Logger:
+		_ = logger.Output(logSkip+2, logOutput)
+	default:
+		_ = logger.Output(logSkip+1, logOutput)
+	}
+}
+
+// execUtility executes a utility statement with the given DB and context. If
+// the Lstats LogFlag is set, it logs how long the statement took prefixed by
+// verb e.g. "(Vacuumed in 1.2s)". If watch is not nil, it is called after the
+// statement is built and before it is executed, and the func that it returns
+// is called once the statement is done. Panics in either are returned as
+// errors.
+func execUtility(ctx context.Context, db DB, q Query, logger Logger, flag LogFlag, logSkip int, verb string, watch func() (stop func())) (err error) {
+	logBuf := &strings.Builder{}
+	start := time.Now()
+	poolBefore := poolStats(flag, db)
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			default:
+				err = fmt.Errorf("%#v", r)
+			}
+			return
+		}
+		if logger == nil {
+			return
+		}
+		elapsed := time.Since(start)
+		if Lstats&flag != 0 {
+			logBuf.WriteString("\n(")
+			logBuf.WriteString(verb)
+			logBuf.WriteString(" in ")
+			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(flag, db))
+			logBuf.WriteString(")")
+		}
+		if logBuf.Len() > 0 {
+			switch logger.(type) {
+			case *log.Logger:
+				_ = logger.Output(logSkip+2, logBuf.String())
+			default:
+				_ = logger.Output(logSkip+1, logBuf.String())
+			}
+		}
+	}()
+	tmpbuf := &strings.Builder{}
+	var tmpargs []interface{}
+	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	if watch != nil {
+		defer watch()()
+	}
+	if ctx == nil {
+		_, err = db.Exec(tmpbuf.String(), tmpargs...)
+	} else {
+		_, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package sq
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMaintenanceQueries_ToSQL(t *testing.T) {
+	type TT struct {
+		description string
+		q           Query
+		wantQuery   string
+	}
+	u, a := USERS(), APPLICATIONS()
+	tests := []TT{
+		{"analyze everything", Analyze(), "ANALYZE"},
+		{"analyze tables", Analyze(u, a.As("a")).Verbose(), "ANALYZE VERBOSE public.users, public.applications"},
+		{"vacuum everything", Vacuum(), "VACUUM"},
+		{"vacuum full analyze", Vacuum(u).Full().Analyze(), "VACUUM (FULL, ANALYZE) public.users"},
+		{"vacuum all options", Vacuum(u, a).Verbose().Analyze().Freeze().Full(), "VACUUM (FULL, FREEZE, VERBOSE, ANALYZE) public.users, public.applications"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(0, len(gotArgs))
+		})
+	}
+}
+
+func TestMaintenanceQueries_Exec(t *testing.T) {
+	is := is.New(t)
+	u := USERS()
+	dbErr := errors.New("database reached")
+	db := recordDB{errDB: errDB{dbErr}, queries: &[]string{}}
+	base := WithDB(db)
+	is.Equal(dbErr, base.Analyze(u).Exec(nil))
+	is.Equal(dbErr, base.Vacuum(u).Full().Analyze().ExecContext(nil, nil))
+	is.Equal([]string{"ANALYZE public.users", "VACUUM (FULL, ANALYZE) public.users"}, *db.queries)
+	is.True(Vacuum(u).Exec(nil) != nil) // no DB
+}
@@ -0,0 +1,313 @@
+package sq
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PredicateGuard guards against queries that touch a table without filtering
+// on one of its mandatory columns, such as the tenant_id column of a
+// multi-tenant schema. A forgotten WHERE clause on such a table would
+// otherwise silently read or modify every tenant's rows.
+//
+// A table is considered filtered if the WHERE clause of the query contains a
+// top level 'column = value' or 'column IN (values)' predicate on that
+// table's mandatory column. Predicates in JOIN conditions, subqueries or CTEs
+// are not considered. An INSERT into a guarded table must list the mandatory
+// column in its insert columns. The guard is applied when the query is
+// executed with Fetch or Exec, not by ToSQL.
+type PredicateGuard struct {
+	// Columns maps a table name (excluding the schema) to the name of the
+	// column that every query touching the table must filter on.
+	Columns map[string]string
+	// If Value is nil, queries missing a mandatory predicate are rejected with
+	// a GuardError. Otherwise the missing 'column = Value' predicates are
+	// added to the WHERE clause of the query, and queries that filter on or
+	// insert any other value in a mandatory column are rejected with a
+	// GuardError. A filter whose values are not known until the query runs,
+	// such as a subquery, is kept and the 'column = Value' predicate is added
+	// alongside it.
+	Value interface{}
+}
+
+// GuardError is returned when a query touches a table without filtering on
+// the column that the PredicateGuard mandates for it, or when it filters on
+// or inserts a value other than the PredicateGuard's Value.
+type GuardError struct {
+	Table  string
+	Column string
+	// Value is the conflicting value formatted with %v, or empty if the
+	// column was not filtered on at all.
+	Value string
+}
+
+// Error implements the error interface.
+func (e GuardError) Error() string {
+	if e.Value != "" {
+		return fmt.Sprintf("sq: query touches table %s with %s.%s = %s instead of the guarded value", e.Table, e.Table, e.Column, e.Value)
+	}
+	return fmt.Sprintf("sq: query touches table %s without a predicate on %s.%s", e.Table, e.Table, e.Column)
+}
+
+// guard returns the predicates that have to be added to the WHERE clause to
+// satisfy the PredicateGuard, or a GuardError if the guard does not amend
+// queries or a filter conflicts with its Value.
+func (g *PredicateGuard) guard(tables []Table, predicates []Predicate) ([]Predicate, error) {
+	if g == nil || len(g.Columns) == 0 {
+		return nil, nil
+	}
+	// filters maps each filtered column to the values of its filters, with a
+	// nil entry for a filter whose values are unknown
+	filters := map[string][][]interface{}{}
+	for _, predicate := range predicates {
+		if table, column, values, ok := filteredColumn(predicate); ok {
+			filters[table+"."+column] = append(filters[table+"."+column], values)
+		}
+	}
+	var missing []GuardError
+	seen := map[string]bool{}
+	for _, table := range tables {
+		if _, ok := table.(BaseTable); !ok {
+			continue
+		}
+		column, ok := g.Columns[table.GetName()]
+		if !ok {
+			continue
+		}
+		qualifier := getAliasOrName(table)
+		if seen[qualifier] {
+			continue
+		}
+		seen[qualifier] = true
+		filtered, ok := filters[qualifier+"."+column]
+		if g.Value == nil {
+			if !ok {
+				return nil, GuardError{Table: qualifier, Column: column}
+			}
+			continue
+		}
+		verified := false
+		for _, values := range filtered {
+			if values == nil {
+				continue
+			}
+			for _, value := range values {
+				if !sameValue(value, g.Value) {
+					return nil, GuardError{Table: qualifier, Column: column, Value: fmt.Sprint(value)}
+				}
+			}
+			verified = true
+		}
+		if !verified {
+			missing = append(missing, GuardError{Table: qualifier, Column: column})
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i].Table < missing[j].Table })
+	var amendments []Predicate
+	for _, m := range missing {
+		amendments = append(amendments, CustomPredicate{
+			Format: "? = ?",
+			Values: []interface{}{FieldLiteral(m.Table + "." + m.Column), g.Value},
+		})
+	}
+	return amendments, nil
+}
+
+// filteredColumn reports the table qualifier and name of the column that the
+// predicate restricts to a definite set of values i.e. 'column = value' or
+// 'column IN (values)', along with those values. The values are nil if they
+// are SQL expressions that are only known when the query is run.
+func filteredColumn(predicate Predicate) (table, column string, values []interface{}, ok bool) {
+	p, ok := predicate.(CustomPredicate)
+	if !ok || p.Negative || len(p.Values) != 2 {
+		return "", "", nil, false
+	}
+	switch p.Format {
+	case "? = ?", "? IN ?", "? IN (?)":
+	default:
+		return "", "", nil, false
+	}
+	// 'a.column = b.column' does not restrict either column to any value
+	leftTable, leftName := fieldColumn(p.Values[0])
+	rightTable, rightName := fieldColumn(p.Values[1])
+	switch {
+	case leftTable != nil && rightTable == nil:
+		return getAliasOrName(leftTable), leftName, filterValues(p.Values[1]), true
+	case leftTable == nil && rightTable != nil && p.Format == "? = ?":
+		return getAliasOrName(rightTable), rightName, filterValues(p.Values[0]), true
+	}
+	return "", "", nil, false
+}
+
+// filterValues returns the values that the value of a predicate is bound as,
+// mirroring appendSQLValue: a slice is expanded into its elements. It returns
+// nil if the value is written into the query as SQL, such as a Field or a
+// subquery.
+func filterValues(value interface{}) []interface{} {
+	switch value.(type) {
+	case nil:
+		return []interface{}{nil}
+	case interface {
+		AppendSQLExclude(*strings.Builder, *[]interface{}, map[string]int, []string)
+	}, interface {
+		AppendSQL(*strings.Builder, *[]interface{}, map[string]int)
+	}:
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice {
+		return []interface{}{value}
+	}
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values
+}
+
+// sameValue reports whether the two values are bound to the same database
+// value, so that e.g. int(7) and int64(7) are the same.
+func sameValue(a, b interface{}) bool {
+	aValue, aErr := driver.DefaultParameterConverter.ConvertValue(a)
+	bValue, bErr := driver.DefaultParameterConverter.ConvertValue(b)
+	if aErr != nil || bErr != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+// fieldColumn returns the table and name of the value if it is a table
+// column, otherwise it returns a nil Table.
+func fieldColumn(value interface{}) (Table, string) {
+	switch f := value.(type) {
+	case ArrayField:
+		return f.table, f.name
+	case BinaryField:
+		return f.table, f.name
+	case BooleanField:
+		return f.table, f.name
+	case JSONField:
+		return f.table, f.name
+	case NumberField:
+		return f.table, f.name
+	case StringField:
+		return f.table, f.name
+	case TimeField:
+		return f.table, f.name
+	}
+	return nil, ""
+}
+
+// amendWhere returns the WHERE predicate with the amendments ANDed onto it.
+func amendWhere(where VariadicPredicate, amendments []Predicate) VariadicPredicate {
+	if len(amendments) == 0 {
+		return where
+	}
+	if len(where.Predicates) == 0 {
+		return VariadicPredicate{Predicates: amendments}
+	}
+	// Only an AND can take the amendments as more of its predicates. Anything
+	// else, even an OR of a single predicate, is wrapped so that the
+	// amendments cannot be ORed onto it.
+	if where.Negative || (where.Operator != "" && where.Operator != PredicateAnd) {
+		return VariadicPredicate{Predicates: append([]Predicate{where}, amendments...)}
+	}
+	predicates := make([]Predicate, 0, len(where.Predicates)+len(amendments))
+	predicates = append(predicates, where.Predicates...)
+	where.Predicates = append(predicates, amendments...)
+	return where
+}
+
+func (q SelectQuery) applyPredicateGuard() (SelectQuery, error) {
+	amendments, err := q.PredicateGuard.guard(q.Tables(), q.Predicates())
+	if err != nil {
+		return q, err
+	}
+	q.WherePredicate = amendWhere(q.WherePredicate, amendments)
+	return q, nil
+}
+
+func (q InsertQuery) applyPredicateGuard() (InsertQuery, error) {
+	if q.PredicateGuard == nil || len(q.PredicateGuard.Columns) == 0 {
+		return q, nil
+	}
+	if column, ok := q.PredicateGuard.Columns[q.IntoTable.GetName()]; ok {
+		var err error
+		q, err = q.guardInsertColumn(column)
+		if err != nil {
+			return q, err
+		}
+	}
+	if q.SelectQuery == nil {
+		return q, nil
+	}
+	selectQuery := *q.SelectQuery
+	selectQuery.PredicateGuard = q.PredicateGuard
+	selectQuery, err := selectQuery.applyPredicateGuard()
+	if err != nil {
+		return q, err
+	}
+	q.SelectQuery = &selectQuery
+	return q, nil
+}
+
+// guardInsertColumn checks that the InsertQuery inserts the guarded column of
+// its IntoTable and, if the PredicateGuard has a Value, that every row of
+// values sets the column to that Value. The rows of an INSERT with a
+// SelectQuery are only known when the query is run, so only the presence of
+// the column is checked for them. The ColumnMapper, if any, is run so that
+// its rows can be checked.
+func (q InsertQuery) guardInsertColumn(column string) (InsertQuery, error) {
+	if q.ColumnMapper != nil {
+		col := &Column{mode: colmodeInsert, fill: q.ColumnFill}
+		q.ColumnMapper(col)
+		col.endRow()
+		q.InsertColumns = col.insertColumns
+		q.RowValues = col.rowValues
+		q.ColumnMapper = nil
+	}
+	qualifier := getAliasOrName(q.IntoTable)
+	index := -1
+	for i, field := range q.InsertColumns {
+		if field.GetName() == column {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return q, GuardError{Table: qualifier, Column: column}
+	}
+	if q.PredicateGuard.Value == nil {
+		return q, nil
+	}
+	for _, rowValue := range q.RowValues {
+		if index >= len(rowValue) {
+			return q, GuardError{Table: qualifier, Column: column}
+		}
+		if value := rowValue[index]; !sameValue(value, q.PredicateGuard.Value) {
+			return q, GuardError{Table: qualifier, Column: column, Value: fmt.Sprint(value)}
+		}
+	}
+	return q, nil
+}
+
+func (q UpdateQuery) applyPredicateGuard() (UpdateQuery, error) {
+	amendments, err := q.PredicateGuard.guard(q.Tables(), q.Predicates())
+	if err != nil {
+		return q, err
+	}
+	q.WherePredicate = amendWhere(q.WherePredicate, amendments)
+	return q, nil
+}
+
+func (q DeleteQuery) applyPredicateGuard() (DeleteQuery, error) {
+	amendments, err := q.PredicateGuard.guard(q.Tables(), q.Predicates())
+	if err != nil {
+		return q, err
+	}
+	q.WherePredicate = amendWhere(q.WherePredicate, amendments)
+	return q, nil
+}
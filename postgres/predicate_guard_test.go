@@ -0,0 +1,211 @@
+package sq
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPredicateGuard(t *testing.T) {
+	type TT struct {
+		description string
+		guard       PredicateGuard
+		q           Query
+		wantErr     error
+		wantQuery   string
+		wantArgs    []interface{}
+	}
+	u, s := USERS().As("u"), SESSIONS().As("s")
+	reject := PredicateGuard{Columns: map[string]string{"users": "user_id", "sessions": "user_id"}}
+	amend := PredicateGuard{Columns: reject.Columns, Value: 7}
+	applyGuard := func(guard PredicateGuard, q Query) (Query, error) {
+		switch q := q.(type) {
+		case SelectQuery:
+			q.PredicateGuard = &guard
+			return q.applyPredicateGuard()
+		case InsertQuery:
+			q.PredicateGuard = &guard
+			return q.applyPredicateGuard()
+		case UpdateQuery:
+			q.PredicateGuard = &guard
+			return q.applyPredicateGuard()
+		case DeleteQuery:
+			q.PredicateGuard = &guard
+			return q.applyPredicateGuard()
+		}
+		return q, nil
+	}
+	tests := []TT{
+		{
+			description: "filtered select passes",
+			guard:       reject,
+			q:           Select(u.EMAIL).From(u).Where(u.USER_ID.EqInt(7)),
+			wantQuery:   "SELECT u.email FROM public.users AS u WHERE u.user_id = $1",
+			wantArgs:    []interface{}{7},
+		},
+		{
+			description: "filtered select with IN passes",
+			guard:       reject,
+			q:           Select(u.EMAIL).From(u).Where(u.USER_ID.In([]int{7, 8})),
+			wantQuery:   "SELECT u.email FROM public.users AS u WHERE u.user_id IN ($1, $2)",
+			wantArgs:    []interface{}{7, 8},
+		},
+		{
+			description: "unguarded table passes",
+			guard:       reject,
+			q:           Select(FORMS().NAME).From(FORMS()),
+			wantQuery:   "SELECT forms.name FROM public.forms",
+		},
+		{
+			description: "unfiltered join is rejected",
+			guard:       reject,
+			q:           Select(u.EMAIL).From(u).Join(s, s.USER_ID.Eq(u.USER_ID)).Where(u.USER_ID.EqInt(7)),
+			wantErr:     GuardError{Table: "s", Column: "user_id"},
+		},
+		{
+			description: "column comparison does not count as a filter",
+			guard:       reject,
+			q:           Select(u.EMAIL).From(u).Where(u.USER_ID.Eq(u.USER_ID)),
+			wantErr:     GuardError{Table: "u", Column: "user_id"},
+		},
+		{
+			description: "filter inside OR does not count",
+			guard:       reject,
+			q:           Select(u.EMAIL).From(u).Where(Or(u.USER_ID.EqInt(7), u.EMAIL.IsNull())),
+			wantErr:     GuardError{Table: "u", Column: "user_id"},
+		},
+		{
+			description: "unfiltered tables are amended",
+			guard:       amend,
+			q:           Select(u.EMAIL).From(u).Join(s, s.USER_ID.Eq(u.USER_ID)).Where(u.EMAIL.IsNotNull()),
+			wantQuery: "SELECT u.email FROM public.users AS u JOIN public.sessions AS s ON s.user_id = u.user_id" +
+				" WHERE u.email IS NOT NULL AND s.user_id = $1 AND u.user_id = $2",
+			wantArgs: []interface{}{7, 7},
+		},
+		{
+			description: "filter on another value is rejected",
+			guard:       amend,
+			q:           Select(u.EMAIL).From(u).Where(u.USER_ID.EqInt(8)),
+			wantErr:     GuardError{Table: "u", Column: "user_id", Value: "8"},
+		},
+		{
+			description: "filter with IN on another value is rejected",
+			guard:       amend,
+			q:           Select(u.EMAIL).From(u).Where(u.USER_ID.In([]int{7, 8})),
+			wantErr:     GuardError{Table: "u", Column: "user_id", Value: "8"},
+		},
+		{
+			description: "filter on the value is not amended",
+			guard:       amend,
+			q:           Select(u.EMAIL).From(u).Where(u.USER_ID.In([]int64{7})),
+			wantQuery:   "SELECT u.email FROM public.users AS u WHERE u.user_id IN ($1)",
+			wantArgs:    []interface{}{int64(7)},
+		},
+		{
+			description: "filter on a subquery is amended",
+			guard:       amend,
+			q:           Select(u.EMAIL).From(u).Where(u.USER_ID.In(Select(Fieldf("current_user_id()")))),
+			wantQuery:   "SELECT u.email FROM public.users AS u WHERE u.user_id IN (SELECT current_user_id()) AND u.user_id = $1",
+			wantArgs:    []interface{}{7},
+		},
+		{
+			description: "insert without the column is rejected",
+			guard:       reject,
+			q:           InsertInto(USERS()).Columns(USERS().EMAIL).Values("bob@example.com"),
+			wantErr:     GuardError{Table: "users", Column: "user_id"},
+		},
+		{
+			description: "insert with the column passes",
+			guard:       reject,
+			q:           InsertInto(USERS()).Columns(USERS().USER_ID, USERS().EMAIL).Values(8, "bob@example.com"),
+			wantQuery:   "INSERT INTO public.users (user_id, email) VALUES ($1, $2)",
+			wantArgs:    []interface{}{8, "bob@example.com"},
+		},
+		{
+			description: "insert of another value is rejected",
+			guard:       amend,
+			q:           InsertInto(USERS()).Columns(USERS().USER_ID, USERS().EMAIL).Values(7, "a@example.com").Values(8, "b@example.com"),
+			wantErr:     GuardError{Table: "users", Column: "user_id", Value: "8"},
+		},
+		{
+			description: "insert of an expression is rejected",
+			guard:       amend,
+			q:           InsertInto(USERS()).Columns(USERS().USER_ID, USERS().EMAIL).Values(Fieldf("current_user_id()"), "a@example.com"),
+			wantErr:     GuardError{Table: "users", Column: "user_id", Value: "current_user_id()"},
+		},
+		{
+			description: "insert of the value with Valuesx passes",
+			guard:       amend,
+			q: InsertInto(USERS()).Valuesx(func(col *Column) {
+				col.SetInt64(USERS().USER_ID, 7)
+				col.SetString(USERS().EMAIL, "a@example.com")
+			}),
+			wantQuery: "INSERT INTO public.users (user_id, email) VALUES ($1, $2)",
+			wantArgs:  []interface{}{int64(7), "a@example.com"},
+		},
+		{
+			description: "insert select is amended",
+			guard:       amend,
+			q:           InsertInto(SESSIONS()).Columns(SESSIONS().USER_ID).Select(Select(u.USER_ID).From(u)),
+			wantQuery:   "INSERT INTO public.sessions (user_id) SELECT u.user_id FROM public.users AS u WHERE u.user_id = $1",
+			wantArgs:    []interface{}{7},
+		},
+		{
+			description: "update is rejected",
+			guard:       reject,
+			q:           Update(u).Set(u.EMAIL.SetString("x")),
+			wantErr:     GuardError{Table: "u", Column: "user_id"},
+		},
+		{
+			description: "delete is amended",
+			guard:       amend,
+			q:           DeleteFrom(u).Where(Or(u.EMAIL.IsNull(), u.DISPLAYNAME.IsNull())),
+			wantQuery:   "DELETE FROM public.users AS u WHERE (u.email IS NULL OR u.displayname IS NULL) AND u.user_id = $1",
+			wantArgs:    []interface{}{7},
+		},
+		{
+			description: "OR of a single predicate is amended outside of it",
+			guard:       amend,
+			q:           DeleteFrom(u).Where(Or(u.EMAIL.IsNull())),
+			wantQuery:   "DELETE FROM public.users AS u WHERE u.email IS NULL AND u.user_id = $1",
+			wantArgs:    []interface{}{7},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			q, err := applyGuard(tt.guard, tt.q)
+			if tt.wantErr != nil {
+				is.Equal(tt.wantErr, err)
+				return
+			}
+			is.NoErr(err)
+			gotQuery, gotArgs := q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestPredicateGuard_Exec(t *testing.T) {
+	is := is.New(t)
+	u := USERS()
+	dbErr := errors.New("database reached")
+	base := WithDB(errDB{dbErr}).WithPredicateGuard(PredicateGuard{Columns: map[string]string{"users": "user_id"}})
+	_, err := base.DeleteFrom(u).Where(u.EMAIL.IsNull()).Exec(nil, 0)
+	is.Equal(GuardError{Table: "users", Column: "user_id"}, err)
+	is.Equal("sq: query touches table users without a predicate on users.user_id", err.Error())
+	_, err = base.DeleteFrom(u).Where(u.USER_ID.EqInt(1)).Exec(nil, 0)
+	is.Equal(dbErr, err)
+	// The guard applies to the amended query that is sent to the database
+	db := recordDB{errDB: errDB{dbErr}, queries: &[]string{}}
+	err = From(u).
+		Guard(PredicateGuard{Columns: map[string]string{"users": "user_id"}, Value: 1}).
+		SelectRowx(func(row *Row) { row.String(u.EMAIL) }).
+		Fetch(db)
+	is.Equal(dbErr, err)
+	is.Equal([]string{"SELECT users.email FROM public.users WHERE users.user_id = $1"}, *db.queries)
+}
@@ -15,21 +15,28 @@ type CustomPredicate struct {
 	Format   string
 	Values   []interface{}
 	Negative bool
+	// err is set by Predicatef if the Format looks unsafe
+	err error
 }
 
 // AppendSQLExclude marshals the CustomPredicate into a buffer and args slice.
 func (p CustomPredicate) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
+	if p.err != nil {
+		panic(p.err)
+	}
 	if p.Negative {
 		buf.WriteString("NOT ")
 	}
 	expandValues(buf, args, excludedTableQualifiers, p.Format, p.Values)
 }
 
-// Predicatef creates a new CustomPredicate.
+// Predicatef creates a new CustomPredicate. Like Fieldf, it rejects formats
+// that look like they were built by concatenating values into SQL.
 func Predicatef(format string, values ...interface{}) CustomPredicate {
 	return CustomPredicate{
 		Format: format,
 		Values: values,
+		err:    checkFormat(format),
 	}
 }
 
@@ -0,0 +1,103 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// PrepareTransaction runs PREPARE TRANSACTION on the transaction tx, which
+// must be a *sql.Tx (or any other DB bound to a single connection). The
+// transaction is dissociated from the connection and kept by the database
+// under the global transaction identifier gid until it is committed with
+// CommitPrepared or rolled back with RollbackPrepared, possibly from a
+// different connection.
+//
+// The *sql.Tx must still be ended with Commit or Rollback to release its
+// connection. This does not affect the prepared transaction.
+func PrepareTransaction(tx DB, gid string) error {
+	return PrepareTransactionContext(nil, tx, gid)
+}
+
+// PrepareTransactionContext is like PrepareTransaction but takes in a context.
+func PrepareTransactionContext(ctx context.Context, tx DB, gid string) error {
+	return execTwoPhase(ctx, tx, "PREPARE TRANSACTION", gid)
+}
+
+// CommitPrepared runs COMMIT PREPARED on the transaction previously prepared
+// with the global transaction identifier gid.
+func CommitPrepared(db DB, gid string) error {
+	return CommitPreparedContext(nil, db, gid)
+}
+
+// CommitPreparedContext is like CommitPrepared but takes in a context.
+func CommitPreparedContext(ctx context.Context, db DB, gid string) error {
+	return execTwoPhase(ctx, db, "COMMIT PREPARED", gid)
+}
+
+// RollbackPrepared runs ROLLBACK PREPARED on the transaction previously
+// prepared with the global transaction identifier gid.
+func RollbackPrepared(db DB, gid string) error {
+	return RollbackPreparedContext(nil, db, gid)
+}
+
+// RollbackPreparedContext is like RollbackPrepared but takes in a context.
+func RollbackPreparedContext(ctx context.Context, db DB, gid string) error {
+	return execTwoPhase(ctx, db, "ROLLBACK PREPARED", gid)
+}
+
+// PreparedTransactions returns the global transaction identifiers of the
+// transactions that are currently prepared in the database, oldest first.
+// It is meant for recovering transactions left behind by a coordinator that
+// failed between preparing and committing them.
+func PreparedTransactions(db DB) ([]string, error) {
+	return PreparedTransactionsContext(nil, db)
+}
+
+// PreparedTransactionsContext is like PreparedTransactions but takes in a
+// context.
+func PreparedTransactionsContext(ctx context.Context, db DB) (gids []string, err error) {
+	if db == nil {
+		return nil, errors.New("DB cannot be nil")
+	}
+	query := "SELECT gid FROM pg_prepared_xacts WHERE database = current_database() ORDER BY prepared"
+	var rows *sql.Rows
+	if ctx == nil {
+		rows, err = db.Query(query)
+	} else {
+		rows, err = db.QueryContext(ctx, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var gid string
+		err = rows.Scan(&gid)
+		if err != nil {
+			return nil, err
+		}
+		gids = append(gids, gid)
+	}
+	return gids, rows.Err()
+}
+
+// execTwoPhase runs a two-phase commit statement for the global transaction
+// identifier gid. The statements do not accept placeholders, so the gid is
+// written into the query as a string literal.
+func execTwoPhase(ctx context.Context, db DB, statement, gid string) (err error) {
+	if db == nil {
+		return errors.New("DB cannot be nil")
+	}
+	if gid == "" {
+		return errors.New("global transaction identifier cannot be empty")
+	}
+	query := statement + " '" + strings.ReplaceAll(gid, "'", "''") + "'"
+	if ctx == nil {
+		_, err = db.Exec(query)
+	} else {
+		_, err = db.ExecContext(ctx, query)
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// Exec records the statements given to a recordDB along with its queries.
+func (db recordDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	*db.queries = append(*db.queries, query)
+	return db.errDB.Exec(query, args...)
+}
+
+func (db recordDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	*db.queries = append(*db.queries, query)
+	return db.errDB.ExecContext(ctx, query, args...)
+}
+
+func TestPreparedTransaction(t *testing.T) {
+	is := is.New(t)
+	dbErr := errors.New("database reached")
+	db := recordDB{errDB: errDB{dbErr}, queries: &[]string{}}
+	is.Equal(dbErr, PrepareTransaction(db, "transfer-1"))
+	is.Equal(dbErr, CommitPreparedContext(context.Background(), db, "transfer-1"))
+	is.Equal(dbErr, RollbackPrepared(db, "it's-2"))
+	is.Equal([]string{
+		"PREPARE TRANSACTION 'transfer-1'",
+		"COMMIT PREPARED 'transfer-1'",
+		"ROLLBACK PREPARED 'it''s-2'",
+	}, *db.queries)
+	// An empty gid is rejected before reaching the database
+	err := CommitPrepared(db, "")
+	is.True(err != nil)
+	is.Equal(3, len(*db.queries))
+	is.True(RollbackPrepared(nil, "transfer-1") != nil)
+	_, err = PreparedTransactions(db)
+	is.Equal(dbErr, err)
+	is.Equal("SELECT gid FROM pg_prepared_xacts WHERE database = current_database() ORDER BY prepared", (*db.queries)[3])
+}
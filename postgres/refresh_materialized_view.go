@@ -0,0 +1,120 @@
+package sq
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// MaterializedView is an interface that specialises the BaseTable interface.
+// It covers only materialized views. sqgen implements it for the materialized
+// views that it generates.
+type MaterializedView interface {
+	BaseTable
+	AssertMaterializedView()
+}
+
+// RefreshMaterializedViewQuery represents a REFRESH MATERIALIZED VIEW query.
+type RefreshMaterializedViewQuery struct {
+	nested bool
+	// REFRESH MATERIALIZED VIEW
+	View         MaterializedView
+	IsConcurrent bool
+	// WITH [NO] DATA
+	NoData bool
+	// DB
+	DB DB
+	// Logging
+	Log     Logger
+	LogFlag LogFlag
+	logSkip int
+}
+
+// ToSQL marshals the RefreshMaterializedViewQuery into a query string and args
+// slice.
+func (q RefreshMaterializedViewQuery) ToSQL() (string, []interface{}) {
+	q.logSkip += 1
+	buf := &strings.Builder{}
+	var args []interface{}
+	q.AppendSQL(buf, &args, nil)
+	return buf.String(), args
+}
+
+// AppendSQL marshals the RefreshMaterializedViewQuery into a buffer and args
+// slice.
+func (q RefreshMaterializedViewQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params map[string]int) {
+	// REFRESH MATERIALIZED VIEW
+	buf.WriteString("REFRESH MATERIALIZED VIEW ")
+	if q.IsConcurrent {
+		buf.WriteString("CONCURRENTLY ")
+	}
+	if q.View == nil {
+		buf.WriteString("NULL")
+	} else {
+		q.View.AppendSQL(buf, args, nil)
+	}
+	// WITH [NO] DATA
+	if q.NoData {
+		buf.WriteString(" WITH NO DATA")
+	}
+	if !q.nested {
+		logUtility(buf.String(), q.Log, q.LogFlag, q.logSkip+1)
+	}
+}
+
+// NestThis indicates to the RefreshMaterializedViewQuery that it is nested.
+func (q RefreshMaterializedViewQuery) NestThis() Query {
+	q.nested = true
+	return q
+}
+
+// RefreshMaterializedView creates a new RefreshMaterializedViewQuery.
+func RefreshMaterializedView(view MaterializedView) RefreshMaterializedViewQuery {
+	return RefreshMaterializedViewQuery{
+		View: view,
+	}
+}
+
+// Concurrently refreshes the materialized view without locking out concurrent
+// SELECTs on it. It requires a unique index on the materialized view, and
+// cannot be used WITH NO DATA.
+func (q RefreshMaterializedViewQuery) Concurrently() RefreshMaterializedViewQuery {
+	q.IsConcurrent = true
+	return q
+}
+
+// WithData repopulates the materialized view by running its query. This is
+// the default.
+func (q RefreshMaterializedViewQuery) WithData() RefreshMaterializedViewQuery {
+	q.NoData = false
+	return q
+}
+
+// WithNoData empties the materialized view and leaves it in an unscannable
+// state until it is refreshed WITH DATA.
+func (q RefreshMaterializedViewQuery) WithNoData() RefreshMaterializedViewQuery {
+	q.NoData = true
+	return q
+}
+
+// Exec will execute the RefreshMaterializedViewQuery with the given DB.
+func (q RefreshMaterializedViewQuery) Exec(db DB) (err error) {
+	q.logSkip += 1
+	return q.ExecContext(nil, db)
+}
+
+// ExecContext will execute the RefreshMaterializedViewQuery with the given DB
+// and context.
+func (q RefreshMaterializedViewQuery) ExecContext(ctx context.Context, db DB) (err error) {
+	if db == nil {
+		if q.DB == nil {
+			return errors.New("DB cannot be nil")
+		}
+		db = q.DB
+	}
+	if q.IsConcurrent && q.NoData {
+		return errors.New("REFRESH MATERIALIZED VIEW CONCURRENTLY cannot be used WITH NO DATA")
+	}
+	q.logSkip += 2
+	return execUtility(ctx, db, q, q.Log, q.LogFlag, q.logSkip, "Refreshed", nil)
+}
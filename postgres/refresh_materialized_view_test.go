@@ -0,0 +1,66 @@
+package sq
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type MVIEW_MONTHLY_SALES struct {
+	*TableInfo
+	TOTAL NumberField
+}
+
+func MONTHLY_SALES() MVIEW_MONTHLY_SALES {
+	tbl := MVIEW_MONTHLY_SALES{TableInfo: &TableInfo{Schema: "public", Name: "monthly_sales", ReadOnly: true}}
+	tbl.TOTAL = NewNumberField("total", tbl.TableInfo)
+	return tbl
+}
+
+func (tbl MVIEW_MONTHLY_SALES) As(alias string) MVIEW_MONTHLY_SALES {
+	tbl.TableInfo.Alias = alias
+	return tbl
+}
+
+func (tbl MVIEW_MONTHLY_SALES) AssertMaterializedView() {}
+
+func TestRefreshMaterializedViewQuery_ToSQL(t *testing.T) {
+	type TT struct {
+		description string
+		q           RefreshMaterializedViewQuery
+		wantQuery   string
+	}
+	ms := MONTHLY_SALES()
+	tests := []TT{
+		{"basic", RefreshMaterializedView(ms), "REFRESH MATERIALIZED VIEW public.monthly_sales"},
+		{"aliased", RefreshMaterializedView(ms.As("ms")), "REFRESH MATERIALIZED VIEW public.monthly_sales"},
+		{"concurrently", RefreshMaterializedView(ms).Concurrently().WithData(), "REFRESH MATERIALIZED VIEW CONCURRENTLY public.monthly_sales"},
+		{"with no data", RefreshMaterializedView(ms).WithNoData(), "REFRESH MATERIALIZED VIEW public.monthly_sales WITH NO DATA"},
+		{"nil view", RefreshMaterializedView(nil), "REFRESH MATERIALIZED VIEW NULL"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			gotQuery, gotArgs := tt.q.ToSQL()
+			is.Equal(tt.wantQuery, gotQuery)
+			is.Equal(0, len(gotArgs))
+		})
+	}
+}
+
+func TestRefreshMaterializedViewQuery_Exec(t *testing.T) {
+	is := is.New(t)
+	ms := MONTHLY_SALES()
+	dbErr := errors.New("database reached")
+	db := recordDB{errDB: errDB{dbErr}, queries: &[]string{}}
+	err := WithDB(db).RefreshMaterializedView(ms).Concurrently().Exec(nil)
+	is.Equal(dbErr, err)
+	is.Equal([]string{"REFRESH MATERIALIZED VIEW CONCURRENTLY public.monthly_sales"}, *db.queries)
+	err = RefreshMaterializedView(ms).Concurrently().WithNoData().Exec(db)
+	is.True(err != nil) // CONCURRENTLY cannot be used WITH NO DATA
+	is.Equal(1, len(*db.queries))
+	err = RefreshMaterializedView(ms).Exec(nil)
+	is.True(err != nil) // no DB
+}
@@ -0,0 +1,187 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Preparer is an interface providing the ability to prepare queries. It is
+// implemented by *sql.DB, *sql.Conn and *sql.Tx.
+type Preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// Registry is a set of named queries that can be validated against the
+// database all at once. Applications register their queries at init and call
+// ValidateAll at startup (or in CI), so that a query that no longer matches
+// the database schema is caught before it is run.
+type Registry struct {
+	mu      sync.Mutex
+	names   []string
+	queries map[string]Query
+}
+
+// NewRegistry creates a new, empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{queries: make(map[string]Query)}
+}
+
+// DefaultRegistry is the Registry used by Register and ValidateAll.
+var DefaultRegistry = NewRegistry()
+
+// Register adds the query to the DefaultRegistry under the name.
+func Register(name string, q Query) {
+	DefaultRegistry.Register(name, q)
+}
+
+// ValidateAll validates every query in the DefaultRegistry against the
+// database.
+func ValidateAll(db Preparer) error {
+	return DefaultRegistry.ValidateAll(db)
+}
+
+// Register adds the query to the Registry under the name. It panics if the
+// name is empty or already registered, since registering is meant to happen
+// at init.
+func (r *Registry) Register(name string, q Query) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if name == "" {
+		panic("sq: Register query name is empty")
+	}
+	if q == nil {
+		panic("sq: Register query " + name + " is nil")
+	}
+	if _, dup := r.queries[name]; dup {
+		panic("sq: Register called twice for query " + name)
+	}
+	r.names = append(r.names, name)
+	r.queries[name] = q
+}
+
+// Query returns the query registered under the name.
+func (r *Registry) Query(name string) (q Query, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	q, ok = r.queries[name]
+	return q, ok
+}
+
+// Names returns the names of the registered queries, in the order they were
+// registered.
+func (r *Registry) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, len(r.names))
+	copy(names, r.names)
+	return names
+}
+
+// ValidationError is the error of a registered query that failed validation.
+type ValidationError struct {
+	Name  string
+	Query string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("sq: query %s is invalid: %v", e.Name, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is the list of ValidationErrors returned by ValidateAll.
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface.
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// ValidateAll builds every registered query, checks that it does not use any
+// of the UnsupportedClauses, and PREPAREs it against the database, which
+// checks that the tables, columns and types it refers to exist without
+// running it. It returns ValidationErrors listing every query that failed, or
+// nil if they all passed.
+func (r *Registry) ValidateAll(db Preparer) error {
+	return r.ValidateAllContext(nil, db)
+}
+
+// ValidateAllContext is like ValidateAll but takes in a context.
+func (r *Registry) ValidateAllContext(ctx context.Context, db Preparer) error {
+	if db == nil {
+		return fmt.Errorf("DB cannot be nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var errs ValidationErrors
+	for _, name := range r.Names() {
+		q, _ := r.Query(name)
+		query, _, err := buildQuery(q)
+		if err == nil {
+			err = checkClauses(q)
+		}
+		if err == nil {
+			var stmt *sql.Stmt
+			stmt, err = db.PrepareContext(ctx, query)
+			if err == nil && stmt != nil {
+				err = stmt.Close()
+			}
+		}
+		if err != nil {
+			errs = append(errs, &ValidationError{Name: name, Query: query, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// buildQuery builds the query, returning any error raised while building it
+// instead of panicking or sending it to the database in the args. A query
+// with a mapper is built with the fields of its mapper in its SELECT or
+// RETURNING clause, the way Fetch builds it.
+func buildQuery(q Query) (query string, args []interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				err = v
+			default:
+				err = fmt.Errorf("%#v", r)
+			}
+		}
+	}()
+	switch v := q.(type) {
+	case SelectQuery:
+		q = v.withMapperFields()
+	case InsertQuery:
+		q = v.withMapperFields()
+	case UpdateQuery:
+		q = v.withMapperFields()
+	case DeleteQuery:
+		q = v.withMapperFields()
+	}
+	query, args = q.ToSQL()
+	// InsertQuery and UpdateQuery recover their own panics into the args
+	if query == "" && len(args) == 1 {
+		if err, ok := args[0].(error); ok {
+			return "", nil, err
+		}
+		return "", nil, fmt.Errorf("%#v", args[0])
+	}
+	return query, args, nil
+}
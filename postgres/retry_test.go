@@ -0,0 +1,135 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/matryer/is"
+)
+
+// countDB is an errDB that counts the queries it was given.
+type countDB struct {
+	errDB
+	count *int
+}
+
+func (db countDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	*db.count++
+	return db.errDB.Query(query, args...)
+}
+
+func (db countDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	*db.count++
+	return db.errDB.Exec(query, args...)
+}
+
+func (db countDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	*db.count++
+	return db.errDB.QueryContext(ctx, query, args...)
+}
+
+func (db countDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	*db.count++
+	return db.errDB.ExecContext(ctx, query, args...)
+}
+
+func TestIsTransientError(t *testing.T) {
+	type TT struct {
+		description string
+		err         error
+		want        bool
+	}
+	tests := []TT{
+		{"nil", nil, false},
+		{"bad connection", driver.ErrBadConn, true},
+		{"wrapped bad connection", fmt.Errorf("query failed: %w", driver.ErrBadConn), true},
+		{"serialization failure", &pq.Error{Code: "40001"}, true},
+		{"connection exception class", &pq.Error{Code: "08006"}, true},
+		{"unique violation", &pq.Error{Code: "23505"}, false},
+		{"other", errors.New("syntax error"), false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(tt.want, IsTransientError(tt.err))
+		})
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	is := is.New(t)
+	backoff := ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	is.Equal(10*time.Millisecond, backoff(1))
+	is.Equal(20*time.Millisecond, backoff(2))
+	is.Equal(40*time.Millisecond, backoff(3))
+	is.Equal(50*time.Millisecond, backoff(4))
+	is.Equal(50*time.Millisecond, backoff(10))
+}
+
+func TestRetryPolicy(t *testing.T) {
+	u := USERS()
+	t.Run("retries transient errors", func(t *testing.T) {
+		is := is.New(t)
+		var count int
+		db := countDB{errDB: errDB{driver.ErrBadConn}, count: &count}
+		_, err := DeleteFrom(u).Where(u.USER_ID.EqInt(1)).Retry(RetryPolicy{MaxAttempts: 3}).Exec(db, 0)
+		is.Equal(driver.ErrBadConn, err)
+		is.Equal(3, count)
+	})
+	t.Run("does not retry other errors", func(t *testing.T) {
+		is := is.New(t)
+		var count int
+		dbErr := errors.New("syntax error")
+		db := countDB{errDB: errDB{dbErr}, count: &count}
+		err := WithDB(db).WithRetryPolicy(RetryPolicy{MaxAttempts: 3}).
+			From(u).SelectRowx(func(row *Row) { row.String(u.EMAIL) }).Fetch(nil)
+		is.Equal(dbErr, err)
+		is.Equal(1, count)
+	})
+	t.Run("does not retry writes that may have run by default", func(t *testing.T) {
+		is := is.New(t)
+		var count int
+		dbErr := &pq.Error{Code: "40001"}
+		db := countDB{errDB: errDB{dbErr}, count: &count}
+		_, err := DeleteFrom(u).Where(u.USER_ID.EqInt(1)).Retry(RetryPolicy{MaxAttempts: 3}).Exec(db, 0)
+		is.Equal(dbErr, err)
+		is.Equal(1, count)
+		// Retrying them is opt-in
+		count = 0
+		_, err = DeleteFrom(u).Where(u.USER_ID.EqInt(1)).Retry(RetryPolicy{MaxAttempts: 3, Retryable: IsTransientError}).Exec(db, 0)
+		is.Equal(dbErr, err)
+		is.Equal(3, count)
+	})
+	t.Run("custom classifier", func(t *testing.T) {
+		is := is.New(t)
+		var count int
+		dbErr := errors.New("try again")
+		db := RetryDB(countDB{errDB: errDB{dbErr}, count: &count}, RetryPolicy{
+			MaxAttempts: 2,
+			Retryable:   func(err error) bool { return err == dbErr },
+		})
+		_, err := db.ExecContext(context.Background(), "SELECT 1")
+		is.Equal(dbErr, err)
+		is.Equal(2, count)
+	})
+	t.Run("stops when the context is done", func(t *testing.T) {
+		is := is.New(t)
+		var count int
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		db := RetryDB(countDB{errDB: errDB{driver.ErrBadConn}, count: &count}, RetryPolicy{
+			MaxAttempts: 5,
+			Backoff:     ExponentialBackoff(time.Hour, time.Hour),
+		})
+		_, err := db.QueryContext(ctx, "SELECT 1")
+		is.Equal(driver.ErrBadConn, err)
+		is.Equal(1, count)
+	})
+}
@@ -0,0 +1,58 @@
+package sq
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// SchemaResolver maps a tenant ID to the name of the schema that holds the
+// tenant's tables, for databases where every tenant has its own schema with
+// the same structure.
+type SchemaResolver interface {
+	ResolveSchema(tenantID string) (string, error)
+}
+
+// SchemaResolverFunc is an adapter to allow the use of ordinary functions as
+// SchemaResolvers.
+type SchemaResolverFunc func(tenantID string) (string, error)
+
+// ResolveSchema calls f(tenantID).
+func (f SchemaResolverFunc) ResolveSchema(tenantID string) (string, error) {
+	return f(tenantID)
+}
+
+// SchemaPrefix returns a SchemaResolver that resolves a tenant ID to the
+// prefix followed by the tenant ID e.g. SchemaPrefix("tenant_") resolves
+// "42" to "tenant_42". An empty tenant ID is an error.
+func SchemaPrefix(prefix string) SchemaResolver {
+	return SchemaResolverFunc(func(tenantID string) (string, error) {
+		if tenantID == "" {
+			return "", fmt.Errorf("empty tenant ID")
+		}
+		return prefix + tenantID, nil
+	})
+}
+
+var schemaNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// resolveTenantSchema returns the schema that the resolver resolves the tenant
+// ID of the table to. It panics if there is no resolver or tenant ID, since a
+// tenant table must never fall back to the schema it was generated from, and
+// also if the schema is not a plain identifier since the schema is written
+// into the query as is.
+func resolveTenantSchema(table string, resolver SchemaResolver, tenantID string) string {
+	if resolver == nil {
+		panic(fmt.Errorf("tenant table %s has no SchemaResolver", table))
+	}
+	if tenantID == "" {
+		panic(fmt.Errorf("tenant table %s has no tenant ID", table))
+	}
+	schema, err := resolver.ResolveSchema(tenantID)
+	if err != nil {
+		panic(fmt.Errorf("could not resolve the schema of tenant %q: %w", tenantID, err))
+	}
+	if !schemaNameRegexp.MatchString(schema) {
+		panic(fmt.Errorf("tenant %q resolved to an invalid schema name %q", tenantID, schema))
+	}
+	return schema
+}
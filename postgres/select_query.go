@@ -49,9 +49,12 @@ type SelectQuery struct {
 	// OFFSET
 	OffsetValue *int64
 	// DB
-	DB          DB
-	RowMapper   func(*Row)
-	Accumulator func()
+	DB             DB
+	PredicateGuard *PredicateGuard
+	RetryPolicy    *RetryPolicy
+	RowMapper      func(*Row)
+	Accumulator    func()
+	ExplainBudget  Budget
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -96,6 +99,7 @@ func (q SelectQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 			v.NestThis().AppendSQL(buf, args, nil)
 			buf.WriteString(")")
 		default:
+			appendOnly(buf, q.FromTable)
 			q.FromTable.AppendSQL(buf, args, nil)
 		}
 		alias := q.FromTable.GetAlias()
@@ -408,6 +412,28 @@ func (q SelectQuery) SelectRowx(mapper func(*Row)) SelectQuery {
 	return q
 }
 
+// WithinBudget makes the SelectQuery run EXPLAIN before it is executed, and
+// refuse to execute with a *BudgetError if the query planner estimates that
+// the query will exceed the Budget.
+func (q SelectQuery) WithinBudget(budget Budget) SelectQuery {
+	q.ExplainBudget = budget
+	return q
+}
+
+// Guard sets the PredicateGuard that the SelectQuery is checked (and possibly
+// amended) against when it is executed.
+func (q SelectQuery) Guard(guard PredicateGuard) SelectQuery {
+	q.PredicateGuard = &guard
+	return q
+}
+
+// Retry sets the RetryPolicy that is used to run the SelectQuery again if it fails
+// with a transient error.
+func (q SelectQuery) Retry(policy RetryPolicy) SelectQuery {
+	q.RetryPolicy = &policy
+	return q
+}
+
 // Fetch will run SelectQuery with the given DB. It then maps the results based
 // on the mapper function (and optionally runs the accumulator function).
 func (q SelectQuery) Fetch(db DB) (err error) {
@@ -430,6 +456,7 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	var rowcount int
 	defer func() {
 		if r := recover(); r != nil {
@@ -457,6 +484,7 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			logBuf.WriteString(strconv.Itoa(rowcount))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -471,10 +499,23 @@ func (q SelectQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	r := &Row{}
 	q.RowMapper(r)
 	q.SelectFields = r.fields
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	if q.ExplainBudget != (Budget{}) {
+		err = checkBudget(ctx, db, q.ExplainBudget, tmpbuf.String(), tmpargs)
+		if err != nil {
+			return err
+		}
+	}
 	if ctx == nil {
 		r.rows, err = db.Query(tmpbuf.String(), tmpargs...)
 	} else {
@@ -551,6 +592,7 @@ func (q SelectQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	defer func() {
 		if q.Log == nil {
 			return
@@ -561,6 +603,7 @@ func (q SelectQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -573,10 +616,23 @@ func (q SelectQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 	}()
 	var res sql.Result
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return 0, err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
 	q.AppendSQL(tmpbuf, &tmpargs, nil)
+	if q.ExplainBudget != (Budget{}) {
+		err = checkBudget(ctx, db, q.ExplainBudget, tmpbuf.String(), tmpargs)
+		if err != nil {
+			return rowsAffected, err
+		}
+	}
 	if ctx == nil {
 		res, err = db.Exec(tmpbuf.String(), tmpargs...)
 	} else {
@@ -0,0 +1,310 @@
+package sq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// NormalizeQuery returns the query text that pg_stat_statements records for
+// the Query. The Query is built the way Fetch and Exec build it, so a query
+// with a mapper selects the fields of its mapper. The values passed in as args
+// are already $1, $2 etc placeholders; NormalizeSQL takes care of the
+// constants written into the query itself.
+func NormalizeQuery(q Query) (string, error) {
+	query, _, err := buildQuery(q)
+	if err != nil {
+		return "", err
+	}
+	return NormalizeSQL(query), nil
+}
+
+// NormalizeSQL replaces the constants in a query string with $n placeholders
+// the way pg_stat_statements does, numbering them after the highest
+// placeholder that is already in the query. String literals and numeric
+// literals (including exponents like 1e5, and hexadecimal, octal and binary
+// integers) are replaced, quoted identifiers are left as they are. Escape
+// strings (E'...'), bit strings (B'...' and X'...'), national strings (N'...')
+// and dollar quoted strings ($$...$$ and $tag$...$tag$) are replaced whole. Like in
+// pg_stat_statements, a minus sign that negates a number is part of the
+// constant, while a minus sign that subtracts a number is not.
+func NormalizeSQL(query string) string {
+	n := maxPlaceholder(query)
+	buf := &strings.Builder{}
+	// operand is whether the previous token ends an operand, in which case a
+	// minus sign that follows it is a subtraction rather than a negation
+	operand := false
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '"':
+			end := quotedEnd(query, i, '"')
+			buf.WriteString(query[i:end])
+			i = end
+			operand = true
+		case c == '\'':
+			n++
+			buf.WriteString("$" + strconv.Itoa(n))
+			i = quotedEnd(query, i, '\'')
+			operand = true
+		case isStringPrefix(c) && i+1 < len(query) && query[i+1] == '\'' && (i == 0 || !isWordChar(query[i-1])):
+			n++
+			buf.WriteString("$" + strconv.Itoa(n))
+			if c == 'E' || c == 'e' {
+				i = escapeStringEnd(query, i+1)
+			} else {
+				i = quotedEnd(query, i+1, '\'')
+			}
+			operand = true
+		case c == '$' && isDollarQuote(query, i):
+			n++
+			buf.WriteString("$" + strconv.Itoa(n))
+			i = dollarQuotedEnd(query, i)
+			operand = true
+		case c == '-' && !operand && negatedNumberEnd(query, i) > i:
+			n++
+			buf.WriteString("$" + strconv.Itoa(n))
+			i = negatedNumberEnd(query, i)
+			operand = true
+		case (i == 0 || !isWordChar(query[i-1])) && numberEnd(query, i) > i:
+			n++
+			buf.WriteString("$" + strconv.Itoa(n))
+			i = numberEnd(query, i)
+			operand = true
+		case isWordChar(c) || c == '$':
+			// Skip over identifiers and placeholders so that the digits in
+			// them are not mistaken for numbers
+			end := i + 1
+			for end < len(query) && isWordChar(query[end]) {
+				end++
+			}
+			buf.WriteString(query[i:end])
+			operand = !operatorKeywords[strings.ToUpper(query[i:end])]
+			i = end
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			buf.WriteByte(c)
+			i++
+		default:
+			buf.WriteByte(c)
+			operand = c == ')' || c == ']'
+			i++
+		}
+	}
+	return buf.String()
+}
+
+// operatorKeywords are the keywords after which a minus sign negates the
+// number that follows it instead of subtracting it.
+var operatorKeywords = map[string]bool{
+	"SELECT": true, "WHERE": true, "AND": true, "OR": true, "NOT": true,
+	"CASE": true, "WHEN": true, "THEN": true, "ELSE": true, "BY": true,
+	"LIMIT": true, "OFFSET": true, "IN": true, "IS": true, "BETWEEN": true,
+	"LIKE": true, "ILIKE": true, "HAVING": true, "ON": true, "SET": true,
+	"VALUES": true, "RETURNING": true, "DISTINCT": true, "ALL": true,
+	"ANY": true, "SOME": true, "ARRAY": true, "RETURN": true,
+}
+
+// negatedNumberEnd returns the index just past the numeric literal that the
+// minus sign at query[start] negates, or start if it is not followed by one.
+// Whitespace between the minus sign and the number is allowed.
+func negatedNumberEnd(query string, start int) int {
+	i := start + 1
+	for i < len(query) && (query[i] == ' ' || query[i] == '\t' || query[i] == '\n' || query[i] == '\r') {
+		i++
+	}
+	if end := numberEnd(query, i); end > i {
+		return end
+	}
+	return start
+}
+
+// numberEnd returns the index just past the numeric literal starting at
+// query[start], or start if there is none. It follows the Postgres lexer:
+// digits with an optional fraction and exponent (1, 1.5, .5, 1e5, 2.5E-3),
+// hexadecimal, octal and binary integers (0x1F, 0o17, 0b101) and underscores
+// between digits (1_000).
+func numberEnd(query string, start int) int {
+	if start+2 < len(query) && query[start] == '0' {
+		var isValid func(byte) bool
+		switch query[start+1] {
+		case 'x', 'X':
+			isValid = isHexDigit
+		case 'o', 'O':
+			isValid = func(c byte) bool { return '0' <= c && c <= '7' }
+		case 'b', 'B':
+			isValid = func(c byte) bool { return c == '0' || c == '1' }
+		}
+		if isValid != nil && isValid(query[start+2]) {
+			return digitsEnd(query, start+2, isValid)
+		}
+	}
+	end := digitsEnd(query, start, isDigit)
+	if end < len(query) && query[end] == '.' {
+		if fraction := digitsEnd(query, end+1, isDigit); end > start || fraction > end+1 {
+			end = fraction
+		}
+	}
+	if end == start {
+		return start
+	}
+	if end < len(query) && (query[end] == 'e' || query[end] == 'E') {
+		i := end + 1
+		if i < len(query) && (query[i] == '+' || query[i] == '-') {
+			i++
+		}
+		if i < len(query) && isDigit(query[i]) {
+			end = digitsEnd(query, i, isDigit)
+		}
+	}
+	return end
+}
+
+// digitsEnd returns the index just past the run of digits starting at
+// query[start], allowing single underscores between digits.
+func digitsEnd(query string, start int, isValid func(byte) bool) int {
+	end := start
+	for end < len(query) {
+		if isValid(query[end]) || (query[end] == '_' && end > start && end+1 < len(query) && isValid(query[end+1])) {
+			end++
+			continue
+		}
+		break
+	}
+	return end
+}
+
+// QueryID looks up the queryid that pg_stat_statements has assigned to the
+// Query, so that application metrics about the Query can be joined with the
+// statistics collected by the database. The queryid is computed by the
+// database from the parsed query and cannot be computed by the application,
+// so the Query must have been run at least once since the statistics were last
+// reset. It returns sql.ErrNoRows if the Query is not in pg_stat_statements.
+func QueryID(db DB, q Query) (int64, error) {
+	return QueryIDContext(nil, db, q)
+}
+
+// QueryIDContext is like QueryID but takes in a context.
+func QueryIDContext(ctx context.Context, db DB, q Query) (queryID int64, err error) {
+	if db == nil {
+		return 0, errors.New("DB cannot be nil")
+	}
+	query := "SELECT queryid FROM pg_stat_statements" +
+		" WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database()) AND query = $1" +
+		" LIMIT 1"
+	normalized, err := NormalizeQuery(q)
+	if err != nil {
+		return 0, err
+	}
+	var rows *sql.Rows
+	if ctx == nil {
+		rows, err = db.Query(query, normalized)
+	} else {
+		rows, err = db.QueryContext(ctx, query, normalized)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, sql.ErrNoRows
+	}
+	err = rows.Scan(&queryID)
+	if err != nil {
+		return 0, err
+	}
+	return queryID, rows.Err()
+}
+
+// maxPlaceholder returns the highest $n placeholder in the query, or 0 if
+// there are none.
+func maxPlaceholder(query string) int {
+	var max int
+	for i := strings.Index(query, "$"); i >= 0; {
+		end := i + 1
+		for end < len(query) && isDigit(query[end]) {
+			end++
+		}
+		if n, err := strconv.Atoi(query[i+1 : end]); err == nil && n > max {
+			max = n
+		}
+		next := strings.Index(query[end:], "$")
+		if next < 0 {
+			break
+		}
+		i = end + next
+	}
+	return max
+}
+
+// quotedEnd returns the index just past the quoted string starting at
+// query[start], treating a doubled quote as an escaped quote.
+func quotedEnd(query string, start int, quote byte) int {
+	for i := start + 1; i < len(query); i++ {
+		if query[i] != quote {
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(query)
+}
+
+// escapeStringEnd returns the index just past the E'...' escape string whose
+// opening quote is at query[start]. Inside an escape string a backslash
+// escapes the next character, in addition to a doubled quote.
+func escapeStringEnd(query string, start int) int {
+	for i := start + 1; i < len(query); i++ {
+		switch {
+		case query[i] == '\\':
+			i++
+		case query[i] != '\'':
+			continue
+		case i+1 < len(query) && query[i+1] == '\'':
+			i++
+		default:
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// dollarQuotedEnd returns the index just past the dollar quoted string that
+// starts at query[start], which is closed by the same $tag$ that opened it.
+func dollarQuotedEnd(query string, start int) int {
+	tagEnd := start + 1 + strings.IndexByte(query[start+1:], '$') + 1
+	tag := query[start:tagEnd]
+	if end := strings.Index(query[tagEnd:], tag); end >= 0 {
+		return tagEnd + end + len(tag)
+	}
+	return len(query)
+}
+
+// isStringPrefix reports whether c is one of the letters that can prefix a
+// string constant: E'...', B'...', X'...' or N'...'.
+func isStringPrefix(c byte) bool {
+	switch c {
+	case 'E', 'e', 'B', 'b', 'X', 'x', 'N', 'n':
+		return true
+	}
+	return false
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || isDigit(c) || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
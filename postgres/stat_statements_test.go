@@ -0,0 +1,64 @@
+package sq
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestNormalizeSQL(t *testing.T) {
+	type TT struct {
+		description string
+		query       string
+		want        string
+	}
+	tests := []TT{
+		{"no constants", "SELECT users.email FROM public.users WHERE users.user_id = $1", "SELECT users.email FROM public.users WHERE users.user_id = $1"},
+		{"numbers", "SELECT 1 FROM t1 WHERE t1.x > 2.5 LIMIT $1", "SELECT $2 FROM t1 WHERE t1.x > $3 LIMIT $1"},
+		{"strings", "SELECT 'it''s'::text, \"col 1\" FROM t WHERE t.name = 'x'", "SELECT $1::text, \"col 1\" FROM t WHERE t.name = $2"},
+		{"placeholders above 9", "SELECT 7 WHERE $10 = $2", "SELECT $11 WHERE $10 = $2"},
+		{"exponents", "SELECT 1e5, 2.5E-3, 3e+2, .5 FROM t", "SELECT $1, $2, $3, $4 FROM t"},
+		{"exponent needs digits", "SELECT 1e FROM t", "SELECT $1e FROM t"},
+		{"other bases and underscores", "SELECT 0x1F, 0o17, 0b101, 1_000", "SELECT $1, $2, $3, $4"},
+		{"negative numbers", "SELECT -1, (- 2.5e3) FROM t WHERE t.x = -3 AND t.y IN (-4)", "SELECT $1, ($2) FROM t WHERE t.x = $3 AND t.y IN ($4)"},
+		{"subtraction", "SELECT t.x-1, t.y - 2, (t.z) - 3, $1 - 4 FROM t", "SELECT t.x-$2, t.y - $3, (t.z) - $4, $1 - $5 FROM t"},
+		{"digits in identifiers", "SELECT t1.c2e5 FROM t1", "SELECT t1.c2e5 FROM t1"},
+		{"escape strings", `SELECT E'it\'s', e'a\\', t.e FROM t WHERE t.x = E'b''c'`, "SELECT $1, $2, t.e FROM t WHERE t.x = $3"},
+		{"prefixed strings", "SELECT B'101', X'1F', N'abc', t.x FROM t", "SELECT $1, $2, $3, t.x FROM t"},
+		{"dollar quoted strings", "SELECT $$it's$$, $tag$a $$ b$tag$ WHERE $1 = 1", "SELECT $2, $3 WHERE $1 = $4"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(tt.want, NormalizeSQL(tt.query))
+		})
+	}
+}
+
+func TestQueryID(t *testing.T) {
+	is := is.New(t)
+	u := USERS()
+	q := From(u).Where(u.USER_ID.EqInt(1), Predicatef("users.email LIKE ?", UnsafeLiteral("'%@example.com'"))).Select(u.EMAIL)
+	query, err := NormalizeQuery(q)
+	is.NoErr(err)
+	is.Equal("SELECT users.email FROM public.users WHERE users.user_id = $1 AND users.email LIKE $2", query)
+	// a query with a mapper is normalized with the fields of its mapper
+	query, err = NormalizeQuery(From(u).Where(u.USER_ID.EqInt(1)).Selectx(func(row *Row) { row.String(u.EMAIL) }, nil))
+	is.NoErr(err)
+	is.Equal("SELECT users.email FROM public.users WHERE users.user_id = $1", query)
+	dbErr := errors.New("database reached")
+	db := recordDB{errDB: errDB{dbErr}, queries: &[]string{}}
+	_, err = QueryID(db, q)
+	is.Equal(dbErr, err)
+	is.Equal(1, len(*db.queries))
+	_, err = QueryID(nil, q)
+	is.True(err != nil && err != sql.ErrNoRows)
+	// a query that fails to build is not looked up
+	*db.queries = nil
+	_, err = QueryID(db, From(u).Where(Predicatef("users.email = 'x'")))
+	is.True(err != nil && err != dbErr)
+	is.Equal(0, len(*db.queries))
+}
@@ -1,13 +1,22 @@
 package sq
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // EnumField is a type alias for StringField.
 type EnumField = StringField
 
-// NewEnumField returns an EnumField representing an enum column.
-func NewEnumField(name string, table Table) EnumField {
-	return NewStringField(name, table)
+// NewEnumField returns an EnumField representing an enum column. The values,
+// if provided, are the allowed values of the enum and are checked by queries
+// that call ValidateEnums.
+func NewEnumField(name string, table Table, values ...string) EnumField {
+	f := NewStringField(name, table)
+	for _, value := range values {
+		f.enumValues += enumSeparator + value
+	}
+	return f
 }
 
 // StringField either represents a string column or a literal string value.
@@ -33,8 +42,16 @@ type StringField struct {
 	name       string
 	descending *bool
 	nullsfirst *bool
+	// enumValues are the allowed values of an enum column, each prefixed
+	// with enumSeparator. They are kept in a string so that two StringFields
+	// for the same column still compare equal.
+	enumValues string
 }
 
+// enumSeparator separates the values of StringField.enumValues. It is a NUL
+// byte, which cannot appear in an enum value.
+const enumSeparator = "\x00"
+
 // AppendSQLExclude marshals the StringField into an SQL query and args as
 // described in the StringField internal struct comments.
 func (f StringField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, params map[string]int, excludedTableQualifiers []string) {
@@ -345,3 +362,58 @@ func (f StringField) GetAlias() string {
 func (f StringField) GetName() string {
 	return f.name
 }
+
+// EnumValues returns the allowed values of the StringField if it represents an
+// enum column with known values, otherwise it returns nil.
+func (f StringField) EnumValues() []string {
+	if f.enumValues == "" {
+		return nil
+	}
+	return strings.Split(f.enumValues[len(enumSeparator):], enumSeparator)
+}
+
+// EnumError is returned by queries that call ValidateEnums when a value is not
+// one of the allowed values of an EnumField.
+type EnumError struct {
+	Field EnumField
+	Value string
+}
+
+func (e *EnumError) Error() string {
+	return fmt.Sprintf("%q is not a valid value for %s, expected one of %q", e.Value, e.Field.String(), e.Field.EnumValues())
+}
+
+// validateEnum panics with an *EnumError if the field is an EnumField with
+// known values and the value is a string that is not one of them. Values that
+// are not strings cannot be checked and are let through.
+func validateEnum(field Field, value interface{}) {
+	f, ok := field.(StringField)
+	if !ok || f.enumValues == "" {
+		return
+	}
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case *string:
+		if v == nil {
+			return
+		}
+		s = *v
+	default:
+		return
+	}
+	if strings.Contains(f.enumValues+enumSeparator, enumSeparator+s+enumSeparator) {
+		return
+	}
+	panic(&EnumError{Field: f, Value: s})
+}
+
+// validateEnumAssignments calls validateEnum on every FieldAssignment.
+func validateEnumAssignments(assignments Assignments) {
+	for _, assignment := range assignments {
+		if set, ok := assignment.(FieldAssignment); ok {
+			validateEnum(set.Field, set.Value)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package sq
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // TableInfo is struct that implements the Table interface, containing all the
 // information needed to call itself a Table. It is meant to be embedded in
@@ -9,6 +12,20 @@ type TableInfo struct {
 	Schema string
 	Name   string
 	Alias  string
+	// ReadOnly is set by sqgen for views that can neither be updated nor
+	// inserted into. Inserting into, updating or deleting from a read-only
+	// view fails with a ReadOnlyError before the query reaches the database.
+	ReadOnly bool
+	// SchemaResolver, TenantID and Tenant are set by sqgen for tables
+	// generated from a tenant template schema. A tenant table (one with Tenant
+	// or a SchemaResolver set) is rendered in the schema that the
+	// SchemaResolver resolves the TenantID to, never in Schema: a tenant table
+	// with no SchemaResolver or an empty TenantID fails the query.
+	SchemaResolver SchemaResolver
+	TenantID       string
+	Tenant         bool
+	// only excludes the rows of tables that inherit from this table
+	only bool
 }
 
 // AppendSQL adds the fully qualified table name into the buffer.
@@ -16,13 +33,17 @@ func (tbl *TableInfo) AppendSQL(buf *strings.Builder, args *[]interface{}, param
 	if tbl == nil {
 		return
 	}
-	if tbl.Schema != "" {
-		if strings.ContainsAny(tbl.Schema, " \t") {
+	schema := tbl.Schema
+	if tbl.Tenant || tbl.SchemaResolver != nil {
+		schema = resolveTenantSchema(tbl.Name, tbl.SchemaResolver, tbl.TenantID)
+	}
+	if schema != "" {
+		if strings.ContainsAny(schema, " \t") {
 			buf.WriteString("`")
-			buf.WriteString(tbl.Schema)
+			buf.WriteString(schema)
 			buf.WriteString("`.")
 		} else {
-			buf.WriteString(tbl.Schema)
+			buf.WriteString(schema)
 			buf.WriteString(".")
 		}
 	}
@@ -53,5 +74,95 @@ func (tbl *TableInfo) GetName() string {
 	return tbl.Name
 }
 
+// Only returns a copy of the table that excludes the rows of its child tables
+// (tables that inherit from it, or its partitions) i.e. 'ONLY table'. The
+// fields of the original table can be used with the copy since they share the
+// same table qualifier. ONLY is written where a SELECT, UPDATE or DELETE reads
+// from the table (its FROM, JOIN, UPDATE, DELETE FROM and USING tables). Using
+// the copy anywhere else, such as the table of an INSERT, ANALYZE, VACUUM or
+// CREATE INDEX, fails with an OnlyError.
+func (tbl *TableInfo) Only() *TableInfo {
+	if tbl == nil {
+		return nil
+	}
+	only := *tbl
+	only.only = true
+	return &only
+}
+
+// ForTenant returns a copy of the table that is rendered in the schema of the
+// given tenant. The copy is a tenant table, so if the table has no
+// SchemaResolver the query that uses the copy fails instead of being run
+// against the table's own Schema. Like Only, the fields of the original table
+// can be used with the copy.
+func (tbl *TableInfo) ForTenant(tenantID string) *TableInfo {
+	if tbl == nil {
+		return nil
+	}
+	tenant := *tbl
+	tenant.TenantID = tenantID
+	tenant.Tenant = true
+	return &tenant
+}
+
+// IsReadOnly reports whether the TableInfo is a read-only view.
+func (tbl *TableInfo) IsReadOnly() bool {
+	if tbl == nil {
+		return false
+	}
+	return tbl.ReadOnly
+}
+
+// ReadOnlyError is the error raised by an INSERT, UPDATE or DELETE that
+// targets a read-only view.
+type ReadOnlyError struct {
+	View      string
+	Statement string
+}
+
+// Error implements the error interface.
+func (e ReadOnlyError) Error() string {
+	return fmt.Sprintf("sq: cannot %s %s as it is a read-only view", e.Statement, e.View)
+}
+
+// checkWritable panics with a ReadOnlyError if the table is a read-only view.
+func checkWritable(table Table, statement string) {
+	if v, ok := table.(interface{ IsReadOnly() bool }); ok && v.IsReadOnly() {
+		panic(ReadOnlyError{View: table.GetName(), Statement: statement})
+	}
+}
+
+// isOnly reports whether the TableInfo was returned by Only.
+func (tbl *TableInfo) isOnly() bool {
+	return tbl != nil && tbl.only
+}
+
+// OnlyError is the error raised when a table returned by Only is used in a
+// statement that does not accept ONLY for it.
+type OnlyError struct {
+	Table     string
+	Statement string
+}
+
+// Error implements the error interface.
+func (e OnlyError) Error() string {
+	return fmt.Sprintf("sq: cannot use ONLY %s in %s", e.Table, e.Statement)
+}
+
+// appendOnly writes ONLY into the buffer if the table was returned by Only. It
+// is called just before a table is written in a position that accepts ONLY.
+func appendOnly(buf *strings.Builder, table Table) {
+	if v, ok := table.(interface{ isOnly() bool }); ok && v.isOnly() {
+		buf.WriteString("ONLY ")
+	}
+}
+
+// checkNotOnly panics with an OnlyError if the table was returned by Only.
+func checkNotOnly(table Table, statement string) {
+	if v, ok := table.(interface{ isOnly() bool }); ok && v.isOnly() {
+		panic(OnlyError{Table: table.GetName(), Statement: statement})
+	}
+}
+
 // AssertBaseTable implements the BaseTable interface.
 func (tbl *TableInfo) AssertBaseTable() {}
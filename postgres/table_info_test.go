@@ -1,6 +1,7 @@
 package sq
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -18,6 +19,7 @@ func TestTableInfo_AppendSQL(t *testing.T) {
 		{"empty", nil, "", nil},
 		{"has schema", &TableInfo{Schema: "public", Name: "users"}, "public.users", nil},
 		{"no schema", &TableInfo{Name: "users"}, "users", nil},
+		{"only is written by the query", (&TableInfo{Schema: "public", Name: "users"}).Only(), "public.users", nil},
 		{
 			// https://stackoverflow.com/q/506826
 			// only villians put whitespaces in their schema/table/column names >.>
@@ -40,3 +42,87 @@ func TestTableInfo_AppendSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestTableInfo_Only(t *testing.T) {
+	is := is.New(t)
+	u, s := USERS().As("u"), SESSIONS().As("s")
+	gotQuery, gotArgs := Select(u.EMAIL).From(u.Only()).Where(u.USER_ID.EqInt(1)).ToSQL()
+	is.Equal("SELECT u.email FROM ONLY public.users AS u WHERE u.user_id = $1", gotQuery)
+	is.Equal([]interface{}{1}, gotArgs)
+	gotQuery, _ = Select(u.EMAIL).From(u).Join(s.Only(), s.USER_ID.Eq(u.USER_ID)).ToSQL()
+	is.Equal("SELECT u.email FROM public.users AS u JOIN ONLY public.sessions AS s ON s.user_id = u.user_id", gotQuery)
+	gotQuery, _ = DeleteFrom(u.Only()).Where(u.USER_ID.EqInt(1)).ToSQL()
+	is.Equal("DELETE FROM ONLY public.users AS u WHERE u.user_id = $1", gotQuery)
+	gotQuery, _ = DeleteFrom(u).Using(s.Only()).Where(s.USER_ID.Eq(u.USER_ID)).ToSQL()
+	is.Equal("DELETE FROM public.users AS u USING ONLY public.sessions AS s WHERE s.user_id = u.user_id", gotQuery)
+	gotQuery, _ = Update(u.Only()).Set(u.EMAIL.SetString("a")).From(s.Only()).Where(s.USER_ID.Eq(u.USER_ID)).ToSQL()
+	is.Equal("UPDATE ONLY public.users AS u SET email = $1 FROM ONLY public.sessions AS s WHERE s.user_id = u.user_id", gotQuery)
+	// the original table is unchanged
+	gotQuery, _ = Update(u).Set(u.EMAIL.SetString("a")).ToSQL()
+	is.Equal("UPDATE public.users AS u SET email = $1", gotQuery)
+	// ONLY is rejected where postgres does not accept it
+	_, args := InsertInto(u.Only()).Columns(u.EMAIL).Values("a").ToSQL()
+	is.Equal([]interface{}{OnlyError{Table: "users", Statement: "INSERT INTO"}}, args)
+	dbErr := errors.New("database reached")
+	err := Analyze(u.Only()).Exec(errDB{dbErr})
+	is.Equal(OnlyError{Table: "users", Statement: "ANALYZE"}, err)
+	is.Equal("sq: cannot use ONLY users in ANALYZE", err.Error())
+	err = Vacuum(u.Only()).Exec(errDB{dbErr})
+	is.Equal(OnlyError{Table: "users", Statement: "VACUUM"}, err)
+	err = CreateIndex("users_email_idx", u.Only()).Columns(u.EMAIL).Exec(errDB{dbErr})
+	is.Equal(OnlyError{Table: "users", Statement: "CREATE INDEX"}, err)
+}
+
+func TestTableInfo_ReadOnly(t *testing.T) {
+	is := is.New(t)
+	view := &TableInfo{Schema: "public", Name: "user_stats", ReadOnly: true}
+	wantErr := func(statement string) error {
+		return ReadOnlyError{View: "user_stats", Statement: statement}
+	}
+	dbErr := errors.New("database reached")
+	_, args := InsertInto(view).Columns(FieldLiteral("a")).Values(1).ToSQL()
+	is.Equal([]interface{}{wantErr("INSERT INTO")}, args)
+	_, args = Update(view).Set(NewNumberField("a", view).SetInt(1)).ToSQL()
+	is.Equal([]interface{}{wantErr("UPDATE")}, args)
+	_, err := DeleteFrom(view).Exec(errDB{dbErr}, 0)
+	is.Equal(wantErr("DELETE FROM"), err)
+	is.Equal("sq: cannot DELETE FROM user_stats as it is a read-only view", err.Error())
+	// selecting from a read-only view is fine, as is writing to an updatable one
+	_, err = DeleteFrom(&TableInfo{Schema: "public", Name: "active_users"}).Exec(errDB{dbErr}, 0)
+	is.Equal(dbErr, err)
+	_, args = Select(FieldLiteral("a")).From(view).ToSQL()
+	is.Equal(0, len(args))
+}
+
+func TestTableInfo_SchemaResolver(t *testing.T) {
+	is := is.New(t)
+	users := &TableInfo{Schema: "tenant_template", Name: "users", SchemaResolver: SchemaPrefix("tenant_"), TenantID: "42"}
+	email := NewStringField("email", users)
+	gotQuery, _ := Select(email).From(users).ToSQL()
+	is.Equal("SELECT users.email FROM tenant_42.users", gotQuery)
+	// the fields of the original table can be used with a copy for another tenant
+	gotQuery, _ = Select(email).From(users.ForTenant("7")).ToSQL()
+	is.Equal("SELECT users.email FROM tenant_7.users", gotQuery)
+	// resolved schemas are written into the query as is, so they must be plain identifiers
+	dbErr := errors.New("database reached")
+	_, err := DeleteFrom(users.ForTenant("7; DROP TABLE users")).Exec(errDB{dbErr}, 0)
+	is.True(err != nil && err != dbErr)
+	resolverErr := errors.New("unknown tenant")
+	users.SchemaResolver = SchemaResolverFunc(func(string) (string, error) { return "", resolverErr })
+	_, err = DeleteFrom(users).Exec(errDB{dbErr}, 0)
+	is.True(errors.Is(err, resolverErr))
+	// a tenant table never falls back to its template schema
+	_, err = DeleteFrom(&TableInfo{Schema: "tenant_template", Name: "users", Tenant: true, TenantID: "7"}).Exec(errDB{dbErr}, 0)
+	is.Equal("tenant table users has no SchemaResolver", err.Error())
+	_, err = DeleteFrom(&TableInfo{Schema: "tenant_template", Name: "users", Tenant: true, SchemaResolver: SchemaPrefix("tenant_")}).Exec(errDB{dbErr}, 0)
+	is.Equal("tenant table users has no tenant ID", err.Error())
+	_, err = DeleteFrom(users.ForTenant("")).Exec(errDB{dbErr}, 0)
+	is.Equal("tenant table users has no tenant ID", err.Error())
+	_, err = SchemaPrefix("tenant_").ResolveSchema("")
+	is.True(err != nil)
+	// ForTenant on a table without a SchemaResolver does not silently use its
+	// own schema
+	plain := &TableInfo{Schema: "public", Name: "users"}
+	_, err = DeleteFrom(plain.ForTenant("7")).Exec(errDB{dbErr}, 0)
+	is.Equal("tenant table users has no SchemaResolver", err.Error())
+}
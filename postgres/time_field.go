@@ -5,7 +5,8 @@ import (
 	"time"
 )
 
-// TimeField either represents a time column or a literal time.Time value.
+// TimeField either represents a time column, a literal time.Time value or the
+// current time.
 type TimeField struct {
 	// TimeField will be one of the following:
 
@@ -16,7 +17,18 @@ type TimeField struct {
 	// | ?     | time.Now() |
 	value *time.Time
 
-	// 2) Time column
+	// 2) The current time
+	// Examples of the current time:
+	// | query | args           |
+	// |-------|----------------|
+	// | NOW() |                |
+	// | ?     | <clock time>   |
+	now bool
+	// clock is the Clock of the BaseQuery whose Now returned the TimeField. It
+	// is a pointer so that TimeFields stay comparable.
+	clock *Clock
+
+	// 3) Time column
 	// Examples of time columns:
 	// | query            | args |
 	// |------------------|------|
@@ -38,8 +50,16 @@ func (f TimeField) AppendSQLExclude(buf *strings.Builder, args *[]interface{}, p
 		// 1) Literal time.Time value
 		buf.WriteString("?")
 		*args = append(*args, *f.value)
+	case f.now:
+		// 2) The current time
+		if f.clock != nil {
+			buf.WriteString("?")
+			*args = append(*args, (*f.clock)())
+		} else {
+			buf.WriteString("NOW()")
+		}
 	default:
-		// 2) Time column
+		// 3) Time column
 		tableQualifier := f.table.GetAlias()
 		if tableQualifier == "" {
 			tableQualifier = f.table.GetName()
@@ -99,6 +119,15 @@ func Time(t time.Time) TimeField {
 	}
 }
 
+// Now returns a new TimeField representing the current time. It is rendered as
+// NOW(). Use the Now method of a BaseQuery with a Clock for a current time that
+// is rendered from the Clock instead.
+func Now() TimeField {
+	return TimeField{
+		now: true,
+	}
+}
+
 // Set returns a FieldAssignment associating the TimeField to the value i.e.
 // 'field = value'.
 func (f TimeField) Set(value interface{}) FieldAssignment {
@@ -29,10 +29,15 @@ type UpdateQuery struct {
 	// RETURNING
 	ReturningFields Fields
 	// DB
-	DB           DB
-	ColumnMapper func(*Column)
-	RowMapper    func(*Row)
-	Accumulator  func()
+	DB               DB
+	PredicateGuard   *PredicateGuard
+	RetryPolicy      *RetryPolicy
+	ConstraintErrors ConstraintErrors
+	ColumnMapper     func(*Column)
+	RowMapper        func(*Row)
+	Accumulator      func()
+	// Validation
+	EnumValidation bool
 	// Logging
 	Log     Logger
 	LogFlag LogFlag
@@ -62,6 +67,9 @@ func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 		q.ColumnMapper(col)
 		q.Assignments = col.assignments
 	}
+	if q.EnumValidation {
+		validateEnumAssignments(q.Assignments)
+	}
 	// WITH
 	if !q.nested {
 		appendCTEs(buf, args, q.CTEs, q.FromTable, q.JoinTables)
@@ -71,6 +79,8 @@ func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 	if q.UpdateTable == nil {
 		buf.WriteString("NULL")
 	} else {
+		checkWritable(q.UpdateTable, "UPDATE")
+		appendOnly(buf, q.UpdateTable)
 		q.UpdateTable.AppendSQL(buf, args, nil)
 		name := q.UpdateTable.GetName()
 		alias := q.UpdateTable.GetAlias()
@@ -96,6 +106,7 @@ func (q UpdateQuery) AppendSQL(buf *strings.Builder, args *[]interface{}, params
 			v.NestThis().AppendSQL(buf, args, nil)
 			buf.WriteString(")")
 		default:
+			appendOnly(buf, q.FromTable)
 			q.FromTable.AppendSQL(buf, args, nil)
 		}
 		alias := q.FromTable.GetAlias()
@@ -176,6 +187,13 @@ func (q UpdateQuery) Setx(mapper func(*Column)) UpdateQuery {
 	return q
 }
 
+// ValidateEnums makes the UpdateQuery check that every string assigned to an
+// EnumField is one of its allowed values, returning an *EnumError otherwise.
+func (q UpdateQuery) ValidateEnums() UpdateQuery {
+	q.EnumValidation = true
+	return q
+}
+
 // From specifies a table to select from for the purposes of the UpdateQuery.
 func (q UpdateQuery) From(table Table) UpdateQuery {
 	q.FromTable = table
@@ -278,6 +296,27 @@ func (q UpdateQuery) ReturningRowx(mapper func(*Row)) UpdateQuery {
 	return q
 }
 
+// MapConstraintErrors sets the errors to be returned by the UpdateQuery in place
+// of database errors that violate the named constraints.
+func (q UpdateQuery) MapConstraintErrors(errs ConstraintErrors) UpdateQuery {
+	q.ConstraintErrors = errs
+	return q
+}
+
+// Guard sets the PredicateGuard that the UpdateQuery is checked (and possibly
+// amended) against when it is executed.
+func (q UpdateQuery) Guard(guard PredicateGuard) UpdateQuery {
+	q.PredicateGuard = &guard
+	return q
+}
+
+// Retry sets the RetryPolicy that is used to run the UpdateQuery again if it fails
+// with a transient error.
+func (q UpdateQuery) Retry(policy RetryPolicy) UpdateQuery {
+	q.RetryPolicy = &policy
+	return q
+}
+
 // Fetch will run UpdateQuery with the given DB. It then maps the results based
 // on the mapper function (and optionally runs the accumulator function).
 func (q UpdateQuery) Fetch(db DB) (err error) {
@@ -300,6 +339,7 @@ func (q UpdateQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	var rowcount int
 	defer func() {
 		if r := recover(); r != nil {
@@ -327,6 +367,7 @@ func (q UpdateQuery) FetchContext(ctx context.Context, db DB) (err error) {
 			logBuf.WriteString(strconv.Itoa(rowcount))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -341,6 +382,13 @@ func (q UpdateQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	r := &Row{}
 	q.RowMapper(r)
 	q.ReturningFields = r.fields
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
@@ -351,7 +399,7 @@ func (q UpdateQuery) FetchContext(ctx context.Context, db DB) (err error) {
 		r.rows, err = db.QueryContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return err
+		return mapConstraintError(err, q.ConstraintErrors, q.UpdateTable)
 	}
 	defer r.rows.Close()
 	if len(r.dest) == 0 {
@@ -400,7 +448,7 @@ func (q UpdateQuery) FetchContext(ctx context.Context, db DB) (err error) {
 	if e := r.rows.Close(); e != nil {
 		return e
 	}
-	return r.rows.Err()
+	return mapConstraintError(r.rows.Err(), q.ConstraintErrors, q.UpdateTable)
 }
 
 // Exec will execute the UpdateQuery with the given DB. It will only compute
@@ -421,6 +469,7 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 	}
 	logBuf := &strings.Builder{}
 	start := time.Now()
+	poolBefore := poolStats(q.LogFlag, db)
 	defer func() {
 		if r := recover(); r != nil {
 			switch v := r.(type) {
@@ -440,6 +489,7 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 			logBuf.WriteString(strconv.FormatInt(rowsAffected, 10))
 			logBuf.WriteString(" rows in ")
 			logBuf.WriteString(elapsed.String())
+			appendPoolStats(logBuf, poolBefore, poolStats(q.LogFlag, db))
 			logBuf.WriteString(")")
 		}
 		if logBuf.Len() > 0 {
@@ -452,6 +502,13 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		}
 	}()
 	var res sql.Result
+	if q.RetryPolicy != nil {
+		db = RetryDB(db, *q.RetryPolicy)
+	}
+	q, err = q.applyPredicateGuard()
+	if err != nil {
+		return 0, err
+	}
 	tmpbuf := &strings.Builder{}
 	var tmpargs []interface{}
 	q.logSkip += 1
@@ -462,7 +519,7 @@ func (q UpdateQuery) ExecContext(ctx context.Context, db DB, flag ExecFlag) (row
 		res, err = db.ExecContext(ctx, tmpbuf.String(), tmpargs...)
 	}
 	if err != nil {
-		return rowsAffected, err
+		return rowsAffected, mapConstraintError(err, q.ConstraintErrors, q.UpdateTable)
 	}
 	if res != nil && ErowsAffected&flag != 0 {
 		rowsAffected, err = res.RowsAffected()
@@ -0,0 +1,121 @@
+package sqgen
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+)
+
+// Clause is an SQL clause and the name of the query builder method that adds
+// the clause to a query.
+type Clause struct {
+	Name   string
+	Method string
+}
+
+// QueryClauses lists the clauses known to the query builders of any dialect,
+// keyed by the query type.
+var QueryClauses = map[string][]Clause{
+	"SELECT": {
+		{"WITH", "With"},
+		{"DISTINCT", "SelectDistinct"},
+		{"DISTINCT ON", "SelectDistinctOn"},
+		{"FROM", "From"},
+		{"JOIN", "Join"},
+		{"LEFT JOIN", "LeftJoin"},
+		{"RIGHT JOIN", "RightJoin"},
+		{"FULL JOIN", "FullJoin"},
+		{"WHERE", "Where"},
+		{"GROUP BY", "GroupBy"},
+		{"HAVING", "Having"},
+		{"WINDOW", "Window"},
+		{"ORDER BY", "OrderBy"},
+		{"LIMIT", "Limit"},
+		{"OFFSET", "Offset"},
+	},
+	"INSERT": {
+		{"WITH", "With"},
+		{"INSERT IGNORE", "InsertIgnoreInto"},
+		{"VALUES", "Values"},
+		{"SELECT", "Select"},
+		{"ON CONFLICT", "OnConflict"},
+		{"ON CONFLICT ON CONSTRAINT", "OnConflictOnConstraint"},
+		{"ON DUPLICATE KEY UPDATE", "OnDuplicateKeyUpdate"},
+		{"RETURNING", "Returning"},
+	},
+	"UPDATE": {
+		{"WITH", "With"},
+		{"SET", "Set"},
+		{"FROM", "From"},
+		{"JOIN", "Join"},
+		{"LEFT JOIN", "LeftJoin"},
+		{"RIGHT JOIN", "RightJoin"},
+		{"FULL JOIN", "FullJoin"},
+		{"WHERE", "Where"},
+		{"ORDER BY", "OrderBy"},
+		{"LIMIT", "Limit"},
+		{"RETURNING", "Returning"},
+	},
+	"DELETE": {
+		{"WITH", "With"},
+		{"USING", "Using"},
+		{"JOIN", "Join"},
+		{"LEFT JOIN", "LeftJoin"},
+		{"RIGHT JOIN", "RightJoin"},
+		{"FULL JOIN", "FullJoin"},
+		{"WHERE", "Where"},
+		{"ORDER BY", "OrderBy"},
+		{"LIMIT", "Limit"},
+		{"RETURNING", "Returning"},
+	},
+}
+
+// Capabilities is a machine readable matrix of the clauses supported by the
+// query builders of a dialect.
+type Capabilities struct {
+	Dialect string                     `json:"dialect"`
+	Queries map[string]map[string]bool `json:"queries"`
+}
+
+// BuildCapabilities derives the Capabilities of a dialect from its query
+// builders. queries holds a value of the query builder type of each query
+// type, e.g. sq.SelectQuery{} for "SELECT", and unsupported lists the clauses
+// that the query builders can build but the database does not support (the
+// dialect package's UnsupportedClauses, which its validation rejects). Every
+// clause in QueryClauses is present in the matrix, and is supported if the
+// query builder has its method and it is not unsupported.
+func BuildCapabilities(dialect string, queries map[string]interface{}, unsupported map[string][]string) Capabilities {
+	capabilities := Capabilities{
+		Dialect: dialect,
+		Queries: make(map[string]map[string]bool),
+	}
+	for queryType, queryClauses := range QueryClauses {
+		clauses := make(map[string]bool)
+		for _, clause := range queryClauses {
+			clauses[clause.Name] = false
+			if queries[queryType] == nil {
+				continue
+			}
+			if _, ok := reflect.TypeOf(queries[queryType]).MethodByName(clause.Method); ok {
+				clauses[clause.Name] = true
+			}
+		}
+		for _, name := range unsupported[queryType] {
+			clauses[name] = false
+		}
+		capabilities.Queries[queryType] = clauses
+	}
+	return capabilities
+}
+
+// Supports reports whether the query type supports the clause.
+func (c Capabilities) Supports(queryType, clause string) bool {
+	return c.Queries[queryType][clause]
+}
+
+// WriteJSON writes the Capabilities into w as indented JSON.
+func (c Capabilities) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(c)
+}
@@ -0,0 +1,31 @@
+package sqgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type testSelectQuery struct{}
+
+func (testSelectQuery) From()     {}
+func (testSelectQuery) Where()    {}
+func (testSelectQuery) FullJoin() {}
+
+func TestBuildCapabilities(t *testing.T) {
+	is := is.New(t)
+	c := BuildCapabilities("test", map[string]interface{}{"SELECT": testSelectQuery{}}, map[string][]string{"SELECT": {"FULL JOIN"}})
+	is.Equal("test", c.Dialect)
+	is.Equal(len(QueryClauses["SELECT"]), len(c.Queries["SELECT"]))
+	is.Equal(len(QueryClauses["DELETE"]), len(c.Queries["DELETE"]))
+	is.True(c.Supports("SELECT", "FROM"))
+	is.True(c.Supports("SELECT", "WHERE"))
+	is.True(!c.Supports("SELECT", "LIMIT"))     // no method
+	is.True(!c.Supports("SELECT", "FULL JOIN")) // unsupported
+	is.True(!c.Supports("DELETE", "WHERE"))     // no query builder
+	buf := &strings.Builder{}
+	is.NoErr(c.WriteJSON(buf))
+	is.True(strings.Contains(buf.String(), `"WHERE": true`))
+	is.True(strings.Contains(buf.String(), `"LIMIT": false`))
+}
@@ -0,0 +1,17 @@
+package mysql
+
+import (
+	sq "github.com/bokwoon95/go-structured-query/mysql"
+	"github.com/bokwoon95/go-structured-query/sqgen"
+)
+
+// Capabilities returns the capabilities matrix of the mysql dialect, derived
+// from the query builders of the mysql package and its sq.UnsupportedClauses.
+func Capabilities() sqgen.Capabilities {
+	return sqgen.BuildCapabilities("mysql", map[string]interface{}{
+		"SELECT": sq.SelectQuery{},
+		"INSERT": sq.InsertQuery{},
+		"UPDATE": sq.UpdateQuery{},
+		"DELETE": sq.DeleteQuery{},
+	}, sq.UnsupportedClauses)
+}
@@ -0,0 +1,75 @@
+package mysql
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	sq "github.com/bokwoon95/go-structured-query/mysql"
+	"github.com/bokwoon95/go-structured-query/sqgen"
+	"github.com/matryer/is"
+)
+
+func TestCapabilities(t *testing.T) {
+	is := is.New(t)
+	wantSupported := map[string][]string{
+		"SELECT": {
+			"WITH", "DISTINCT", "FROM",
+			"JOIN", "LEFT JOIN", "RIGHT JOIN",
+			"WHERE", "GROUP BY", "HAVING", "WINDOW", "ORDER BY", "LIMIT", "OFFSET",
+		},
+		"INSERT": {
+			"INSERT IGNORE", "VALUES", "SELECT", "ON DUPLICATE KEY UPDATE",
+		},
+		"UPDATE": {
+			"WITH", "SET",
+			"JOIN", "LEFT JOIN", "RIGHT JOIN",
+			"WHERE", "ORDER BY", "LIMIT",
+		},
+		"DELETE": {
+			"WITH", "USING",
+			"JOIN", "LEFT JOIN", "RIGHT JOIN",
+			"WHERE", "ORDER BY", "LIMIT",
+		},
+	}
+	supported := make(map[string][]string)
+	for queryType, clauses := range Capabilities().Queries {
+		for name, ok := range clauses {
+			if ok {
+				supported[queryType] = append(supported[queryType], name)
+			}
+		}
+		sort.Strings(supported[queryType])
+		sort.Strings(wantSupported[queryType])
+	}
+	is.Equal(wantSupported, supported)
+}
+
+// TestUnsupportedClauses checks that every one of sq.UnsupportedClauses is a
+// known clause that the query builders have a method for, since the matrix
+// only tells it apart from the clauses that cannot be built at all.
+func TestUnsupportedClauses(t *testing.T) {
+	queries := map[string]interface{}{
+		"SELECT": sq.SelectQuery{},
+		"INSERT": sq.InsertQuery{},
+		"UPDATE": sq.UpdateQuery{},
+		"DELETE": sq.DeleteQuery{},
+	}
+	for queryType, names := range sq.UnsupportedClauses {
+		for _, name := range names {
+			queryType, name := queryType, name
+			t.Run(queryType+" "+name, func(t *testing.T) {
+				is := is.New(t)
+				var method string
+				for _, clause := range sqgen.QueryClauses[queryType] {
+					if clause.Name == name {
+						method = clause.Method
+					}
+				}
+				is.True(method != "") // unknown clause
+				_, ok := reflect.TypeOf(queries[queryType]).MethodByName(method)
+				is.True(ok) // no builder method for the clause
+			})
+		}
+	}
+}
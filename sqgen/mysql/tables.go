@@ -15,6 +15,11 @@ type Table struct {
 	RawType     string
 	Constructor string
 	Fields      []TableField
+	// set for views that can neither be updated nor inserted into, see
+	// sqgen.ViewReadOnly
+	ReadOnly bool
+	// the CHECK OPTION of a view: NONE, LOCAL or CASCADED
+	CheckOption string
 }
 
 // TableField represents a field in a database table
@@ -29,6 +34,8 @@ type TableField struct {
 	RawTypeEx   string
 	Type        string
 	Constructor string
+	// allowed values of an enum column, parsed from RawTypeEx
+	EnumValues []string
 }
 
 func BuildTables(config Config, writer io.Writer) (int, error) {
@@ -124,6 +131,10 @@ func executeTables(config Config) ([]Table, error) {
 		tableMap[fullTableName].Fields = append(tableMap[fullTableName].Fields, field)
 	}
 
+	if err := executeViews(config, tableMap); err != nil {
+		return nil, sqgen.Wrap(err)
+	}
+
 	var tables []Table
 
 	for _, fullTableName := range orderedTables {
@@ -163,6 +174,47 @@ func buildTablesQuery(schemas, exclude []string) (string, []interface{}) {
 	return query, args
 }
 
+// executeViews marks which of the views in the tableMap are read-only, and
+// records their CHECK OPTION.
+func executeViews(config Config, tableMap map[string]*Table) error {
+	query, args := buildViewsQuery(config.Schemas)
+	rows, err := config.DB.Query(query, args...)
+
+	if err != nil {
+		return sqgen.Wrap(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var viewSchema, viewName, isUpdatable, checkOption string
+
+		if err := rows.Scan(&viewSchema, &viewName, &isUpdatable, &checkOption); err != nil {
+			return err
+		}
+
+		if table, ok := tableMap[viewSchema+"."+viewName]; ok {
+			table.ReadOnly = sqgen.ViewReadOnly(isUpdatable, isUpdatable)
+			table.CheckOption = checkOption
+		}
+	}
+
+	return rows.Err()
+}
+
+func buildViewsQuery(schemas []string) (string, []interface{}) {
+	query := "SELECT table_schema, table_name, is_updatable, check_option" +
+		" FROM information_schema.views" +
+		" WHERE table_schema IN " + sqgen.SliceToSQL(schemas)
+
+	args := make([]interface{}, len(schemas))
+	for i, schema := range schemas {
+		args[i] = schema
+	}
+
+	return query, args
+}
+
 func (table Table) Populate(config *Config, isDuplicate bool) Table {
 	table.StructName = "TABLE_"
 
@@ -260,6 +312,7 @@ func (field TableField) Populate() TableField {
 	case "enum":
 		field.Type = FieldTypeEnum
 		field.Constructor = FieldConstructorEnum
+		field.EnumValues = parseEnumValues(field.RawTypeEx)
 		return field
 	}
 
@@ -273,3 +326,39 @@ func (field TableField) Populate() TableField {
 
 	return field
 }
+
+// parseEnumValues extracts the allowed values from an enum column type e.g.
+// enum('a','b','c') -> []string{"a", "b", "c"}. Single quotes within a value
+// are escaped by doubling them.
+func parseEnumValues(columnType string) []string {
+	if !strings.HasPrefix(columnType, "enum(") || !strings.HasSuffix(columnType, ")") {
+		return nil
+	}
+
+	list := columnType[len("enum(") : len(columnType)-1]
+
+	var values []string
+	var value strings.Builder
+	inQuote := false
+
+	for i := 0; i < len(list); i++ {
+		c := list[i]
+
+		switch {
+		case c == '\'' && inQuote && i+1 < len(list) && list[i+1] == '\'':
+			// doubled single quotes are an escaped single quote
+			value.WriteByte('\'')
+			i++
+		case c == '\'':
+			if inQuote {
+				values = append(values, value.String())
+				value.Reset()
+			}
+			inQuote = !inQuote
+		case inQuote:
+			value.WriteByte(c)
+		}
+	}
+
+	return values
+}
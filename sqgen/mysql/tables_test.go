@@ -56,6 +56,18 @@ func TestBuildTablesQuery(t *testing.T) {
 	})
 }
 
+func TestBuildViewsQuery(t *testing.T) {
+	is := is.New(t)
+
+	query, args := buildViewsQuery([]string{"public", "geo"})
+
+	expectedQuery := "SELECT table_schema, table_name, is_updatable, check_option FROM information_schema.views WHERE table_schema IN (?, ?)"
+	expectedArgs := []interface{}{"public", "geo"}
+
+	is.Equal(query, expectedQuery)
+	is.Equal(args, expectedArgs)
+}
+
 func TestTablePopulate(t *testing.T) {
 	type TT struct {
 		name        string
@@ -343,6 +355,22 @@ func TestTableFieldPopulate(t *testing.T) {
 				Constructor: FieldConstructorEnum,
 			},
 		},
+		{
+			name: "enum field with values",
+			field: TableField{
+				Name:      "status",
+				RawType:   "enum",
+				RawTypeEx: "enum('pending','it''s done')",
+			},
+			result: TableField{
+				Name:        "status",
+				RawType:     "enum",
+				RawTypeEx:   "enum('pending','it''s done')",
+				Type:        FieldTypeEnum,
+				Constructor: FieldConstructorEnum,
+				EnumValues:  []string{"pending", "it's done"},
+			},
+		},
 		{
 			name: "json field",
 			field: TableField{
@@ -37,6 +37,14 @@ import (
 // {{export $table.StructName}} references the {{$table.Schema}}.{{quoteSpace $table.Name}} table.
 {{- else if eq $table.RawType "VIEW"}}
 // {{export $table.StructName}} references the {{$table.Schema}}.{{quoteSpace $table.Name}} view.
+{{- if $table.ReadOnly}}
+//
+// The view is read-only.
+{{- else if and $table.CheckOption (ne $table.CheckOption "NONE")}}
+//
+// The view is updatable WITH {{$table.CheckOption}} CHECK OPTION, rows written
+// through it must remain visible in it.
+{{- end}}
 {{- end}}
 type {{export $table.StructName}} struct {
 	*sq.TableInfo
@@ -58,9 +66,12 @@ func {{export $table.Constructor}}() {{export $table.StructName}} {
 	tbl := {{export $table.StructName}}{TableInfo: &sq.TableInfo{
 		Schema: "{{$table.Schema}}",
 		Name: "{{$table.Name}}",
+		{{- if $table.ReadOnly}}
+		ReadOnly: true,
+		{{- end}}
 	},}
 	{{- range $_, $field := $table.Fields}}
-	tbl.{{export $field.Name}} = {{$field.Constructor}}("{{$field.Name}}", tbl.TableInfo)
+	tbl.{{export $field.Name}} = {{$field.Constructor}}("{{$field.Name}}", tbl.TableInfo{{range $_, $value := $field.EnumValues}}, {{printf "%q" $value}}{{end}})
 	{{- end}}
 	return tbl
 }
@@ -99,3 +99,58 @@ func (tbl TABLE_USERS) As(alias string) TABLE_USERS {
 	_, err = parser.ParseFile(fs, "", out, parser.AllErrors)
 	is.NoErr(err)
 }
+
+func TestTablesTemplate_Views(t *testing.T) {
+	is := is.New(t)
+
+	template, err := getTablesTemplate()
+	is.NoErr(err)
+
+	var writer strings.Builder
+
+	data := TablesTemplateData{
+		PackageName: "tables",
+		Tables: []Table{
+			{
+				Name:        "user_stats",
+				Schema:      "public",
+				StructName:  "VIEW_USER_STATS",
+				RawType:     "VIEW",
+				Constructor: "USER_STATS",
+				ReadOnly:    true,
+				CheckOption: "NONE",
+			},
+			{
+				Name:        "active_users",
+				Schema:      "public",
+				StructName:  "VIEW_ACTIVE_USERS",
+				RawType:     "VIEW",
+				Constructor: "ACTIVE_USERS",
+				CheckOption: "CASCADED",
+			},
+		},
+	}
+
+	err = template.Execute(&writer, data)
+	is.NoErr(err)
+
+	out := writer.String()
+	is.True(strings.Contains(out, `// VIEW_USER_STATS references the public.user_stats view.
+//
+// The view is read-only.
+type VIEW_USER_STATS struct {`))
+	is.True(strings.Contains(out, `tbl := VIEW_USER_STATS{TableInfo: &sq.TableInfo{
+		Schema: "public",
+		Name: "user_stats",
+		ReadOnly: true,
+	},}`))
+	is.True(strings.Contains(out, `// VIEW_ACTIVE_USERS references the public.active_users view.
+//
+// The view is updatable WITH CASCADED CHECK OPTION, rows written
+// through it must remain visible in it.
+type VIEW_ACTIVE_USERS struct {`))
+	is.True(strings.Contains(out, `tbl := VIEW_ACTIVE_USERS{TableInfo: &sq.TableInfo{
+		Schema: "public",
+		Name: "active_users",
+	},}`))
+}
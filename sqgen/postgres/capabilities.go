@@ -0,0 +1,17 @@
+package postgres
+
+import (
+	sq "github.com/bokwoon95/go-structured-query/postgres"
+	"github.com/bokwoon95/go-structured-query/sqgen"
+)
+
+// Capabilities returns the capabilities matrix of the postgres dialect, derived
+// from the query builders of the postgres package and its sq.UnsupportedClauses.
+func Capabilities() sqgen.Capabilities {
+	return sqgen.BuildCapabilities("postgres", map[string]interface{}{
+		"SELECT": sq.SelectQuery{},
+		"INSERT": sq.InsertQuery{},
+		"UPDATE": sq.UpdateQuery{},
+		"DELETE": sq.DeleteQuery{},
+	}, sq.UnsupportedClauses)
+}
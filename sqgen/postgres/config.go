@@ -17,4 +17,12 @@ type Config struct {
 	Exclude []string
 	// Used to log any skipped/unsupported column types
 	Logger sqgen.Logger
+	// Annotate the generated tables that have child tables (tables that
+	// inherit from them, or partitions)
+	AnnotateChildren bool
+	// Generate the tables from this template schema for schema-per-tenant
+	// databases. The generated constructors take a sq.SchemaResolver and
+	// tenant ID that decide the schema at runtime. It cannot be used together
+	// with Schemas
+	TenantTemplate string
 }
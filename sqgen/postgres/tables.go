@@ -3,6 +3,9 @@ package postgres
 
 import (
 	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"io"
 	"strings"
 
@@ -16,6 +19,16 @@ type Table struct {
 	RawType     string
 	Constructor string
 	Fields      []TableField
+	// set for views that can neither be updated nor inserted into, see
+	// sqgen.ViewReadOnly
+	ReadOnly bool
+	// the CHECK OPTION of a view: NONE, LOCAL or CASCADED
+	CheckOption string
+	// fully qualified names of the tables that inherit from the table, only
+	// populated if Config.AnnotateChildren is set
+	Children []string
+	// set if the table was generated from Config.TenantTemplate
+	Tenant bool
 }
 
 type TableField struct {
@@ -23,6 +36,8 @@ type TableField struct {
 	RawType     string
 	Type        string
 	Constructor string
+	// allowed values of an enum column, in the order they were declared
+	EnumValues []string
 }
 
 func BuildTables(config Config, writer io.Writer) (int, error) {
@@ -64,6 +79,13 @@ func BuildTables(config Config, writer io.Writer) (int, error) {
 }
 
 func executeTables(config Config) ([]Table, error) {
+	if config.TenantTemplate != "" {
+		if len(config.Schemas) > 0 {
+			return nil, errors.New("TenantTemplate and Schemas cannot be used together")
+		}
+		config.Schemas = []string{config.TenantTemplate}
+	}
+
 	// Prepare the query and args
 	query, args := buildTablesQuery(config.Schemas, config.Exclude)
 	// Query the database and aggregate the results into a []Table slice
@@ -86,36 +108,32 @@ func executeTables(config Config) ([]Table, error) {
 	// tableMap can't keep track of this order
 	var orderedTables []string
 
-	for rows.Next() {
-		var tableType, tableSchema, tableName, columnName, columnType string
+	if err := scanTableColumns(rows, tableMap, tableNameCount, &orderedTables); err != nil {
+		return nil, err
+	}
 
-		if err := rows.Scan(&tableType, &tableSchema, &tableName, &columnName, &columnType); err != nil {
-			return nil, err
-		}
+	// materialized views are not listed in the information_schema
+	query, args = buildMaterializedViewsQuery(config.Schemas, config.Exclude)
+	mviewRows, err := config.DB.Query(query, args...)
 
-		// used to index the tableMap
-		fullTableName := tableSchema + "." + tableName
+	if err != nil {
+		return nil, sqgen.Wrap(err)
+	}
 
-		// add table to map if not already exists
-		if _, ok := tableMap[fullTableName]; !ok {
-			table := &Table{
-				Schema:  tableSchema,
-				Name:    tableName,
-				RawType: tableType,
-			}
-			tableNameCount[tableName]++
+	defer mviewRows.Close()
 
-			tableMap[fullTableName] = table
-			orderedTables = append(orderedTables, fullTableName)
-		}
+	if err := scanTableColumns(mviewRows, tableMap, tableNameCount, &orderedTables); err != nil {
+		return nil, err
+	}
 
-		// create the field corresponding to row in query
-		field := TableField{
-			Name:    columnName,
-			RawType: columnType,
+	if config.AnnotateChildren {
+		if err := executeChildren(config, tableMap); err != nil {
+			return nil, sqgen.Wrap(err)
 		}
+	}
 
-		tableMap[fullTableName].Fields = append(tableMap[fullTableName].Fields, field)
+	if err := executeViews(config, tableMap); err != nil {
+		return nil, sqgen.Wrap(err)
 	}
 
 	var tables []Table
@@ -124,6 +142,7 @@ func executeTables(config Config) ([]Table, error) {
 		table := tableMap[fullTableName]
 		isDuplicate := tableNameCount[table.Name] > 1
 		t := table.Populate(&config, isDuplicate)
+		t.Tenant = config.TenantTemplate != ""
 
 		tables = append(tables, t)
 	}
@@ -133,6 +152,11 @@ func executeTables(config Config) ([]Table, error) {
 
 func buildTablesQuery(schemas, exclude []string) (string, []interface{}) {
 	query := "SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type" +
+		", COALESCE((SELECT json_agg(e.enumlabel ORDER BY e.enumsortorder)" +
+		" FROM pg_catalog.pg_enum AS e" +
+		" JOIN pg_catalog.pg_type AS ty ON ty.oid = e.enumtypid" +
+		" JOIN pg_catalog.pg_namespace AS n ON n.oid = ty.typnamespace" +
+		" WHERE n.nspname = c.udt_schema AND ty.typname = c.udt_name), '[]')" +
 		" FROM information_schema.tables AS t" +
 		" JOIN information_schema.columns AS c USING (table_schema, table_name)" +
 		" WHERE table_schema IN " + sqgen.SliceToSQL(schemas)
@@ -161,6 +185,167 @@ func buildTablesQuery(schemas, exclude []string) (string, []interface{}) {
 	return q, args
 }
 
+func buildMaterializedViewsQuery(schemas, exclude []string) (string, []interface{}) {
+	// data_type is derived the same way as in information_schema.columns
+	query := "SELECT 'MATERIALIZED VIEW', n.nspname, c.relname, a.attname" +
+		", CASE WHEN ty.typcategory = 'A' THEN 'ARRAY' WHEN ty.typtype = 'e' THEN 'USER-DEFINED' ELSE format_type(a.atttypid, NULL) END" +
+		", COALESCE((SELECT json_agg(e.enumlabel ORDER BY e.enumsortorder)" +
+		" FROM pg_catalog.pg_enum AS e WHERE e.enumtypid = a.atttypid), '[]')" +
+		" FROM pg_catalog.pg_class AS c" +
+		" JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace" +
+		" JOIN pg_catalog.pg_attribute AS a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped" +
+		" JOIN pg_catalog.pg_type AS ty ON ty.oid = a.atttypid" +
+		" WHERE c.relkind = 'm' AND n.nspname IN " + sqgen.SliceToSQL(schemas)
+
+	if len(exclude) > 0 {
+		query += " AND c.relname NOT IN " + sqgen.SliceToSQL(exclude)
+	}
+
+	query += " ORDER BY n.nspname <> 'public', n.nspname, c.relname, a.attname"
+
+	args := make([]interface{}, len(schemas)+len(exclude))
+
+	for i, schema := range schemas {
+		args[i] = schema
+	}
+
+	for i, ex := range exclude {
+		args[i+len(schemas)] = ex
+	}
+
+	return replacePlaceholders(query), args
+}
+
+// scanTableColumns aggregates the rows of the tables (or materialized views)
+// query into the tableMap, one row per column.
+func scanTableColumns(rows *sql.Rows, tableMap map[string]*Table, tableNameCount map[string]int, orderedTables *[]string) error {
+	for rows.Next() {
+		var tableType, tableSchema, tableName, columnName, columnType, enumValues string
+
+		if err := rows.Scan(&tableType, &tableSchema, &tableName, &columnName, &columnType, &enumValues); err != nil {
+			return err
+		}
+
+		// used to index the tableMap
+		fullTableName := tableSchema + "." + tableName
+
+		// add table to map if not already exists
+		if _, ok := tableMap[fullTableName]; !ok {
+			table := &Table{
+				Schema:  tableSchema,
+				Name:    tableName,
+				RawType: tableType,
+			}
+			tableNameCount[tableName]++
+
+			tableMap[fullTableName] = table
+			*orderedTables = append(*orderedTables, fullTableName)
+		}
+
+		// create the field corresponding to row in query
+		field := TableField{
+			Name:    columnName,
+			RawType: columnType,
+		}
+
+		// enum labels are aggregated into a JSON array by the query
+		if err := json.Unmarshal([]byte(enumValues), &field.EnumValues); err != nil {
+			return sqgen.Wrap(err)
+		}
+
+		tableMap[fullTableName].Fields = append(tableMap[fullTableName].Fields, field)
+	}
+
+	return rows.Err()
+}
+
+// executeViews marks which of the views in the tableMap are read-only, and
+// records their CHECK OPTION.
+func executeViews(config Config, tableMap map[string]*Table) error {
+	query, args := buildViewsQuery(config.Schemas)
+	rows, err := config.DB.Query(query, args...)
+
+	if err != nil {
+		return sqgen.Wrap(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var viewSchema, viewName, isUpdatable, isInsertableInto, checkOption string
+
+		if err := rows.Scan(&viewSchema, &viewName, &isUpdatable, &isInsertableInto, &checkOption); err != nil {
+			return err
+		}
+
+		if table, ok := tableMap[viewSchema+"."+viewName]; ok {
+			table.ReadOnly = sqgen.ViewReadOnly(isUpdatable, isInsertableInto)
+			table.CheckOption = checkOption
+		}
+	}
+
+	return rows.Err()
+}
+
+func buildViewsQuery(schemas []string) (string, []interface{}) {
+	query := "SELECT table_schema, table_name, is_updatable, is_insertable_into, check_option" +
+		" FROM information_schema.views" +
+		" WHERE table_schema IN " + sqgen.SliceToSQL(schemas)
+
+	args := make([]interface{}, len(schemas))
+
+	for i, schema := range schemas {
+		args[i] = schema
+	}
+
+	return replacePlaceholders(query), args
+}
+
+// executeChildren populates the Children of the tables in the tableMap.
+func executeChildren(config Config, tableMap map[string]*Table) error {
+	query, args := buildChildrenQuery(config.Schemas)
+	rows, err := config.DB.Query(query, args...)
+
+	if err != nil {
+		return sqgen.Wrap(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var parentSchema, parentName, childSchema, childName string
+
+		if err := rows.Scan(&parentSchema, &parentName, &childSchema, &childName); err != nil {
+			return err
+		}
+
+		if table, ok := tableMap[parentSchema+"."+parentName]; ok {
+			table.Children = append(table.Children, childSchema+"."+childName)
+		}
+	}
+
+	return rows.Err()
+}
+
+func buildChildrenQuery(schemas []string) (string, []interface{}) {
+	query := "SELECT pn.nspname, pc.relname, cn.nspname, cc.relname" +
+		" FROM pg_catalog.pg_inherits AS i" +
+		" JOIN pg_catalog.pg_class AS pc ON pc.oid = i.inhparent" +
+		" JOIN pg_catalog.pg_namespace AS pn ON pn.oid = pc.relnamespace" +
+		" JOIN pg_catalog.pg_class AS cc ON cc.oid = i.inhrelid" +
+		" JOIN pg_catalog.pg_namespace AS cn ON cn.oid = cc.relnamespace" +
+		" WHERE pn.nspname IN " + sqgen.SliceToSQL(schemas) +
+		" ORDER BY pn.nspname, pc.relname, cn.nspname, cc.relname"
+
+	args := make([]interface{}, len(schemas))
+
+	for i, schema := range schemas {
+		args[i] = schema
+	}
+
+	return replacePlaceholders(query), args
+}
+
 // used in templates
 
 // Adds constructor and struct names to table, populates Fields
@@ -170,8 +355,13 @@ func (table Table) Populate(config *Config, isDuplicate bool) Table {
 	// RawTypes that can appear, consult this link (look for table_type):
 	// https://www.postgresql.org/docs/current/infoschema-tables.html
 	table.StructName = "TABLE_"
-	if table.RawType == "VIEW" {
+	switch table.RawType {
+	case "VIEW":
 		table.StructName = "VIEW_"
+	case "MATERIALIZED VIEW":
+		// materialized views are only written to by refreshing them
+		table.StructName = "MVIEW_"
+		table.ReadOnly = true
 	}
 
 	// Add schema prefix to struct name and constructor if more than one table share same name
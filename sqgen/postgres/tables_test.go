@@ -15,7 +15,7 @@ func TestBuildTablesQuery(t *testing.T) {
 
 		query, args := buildTablesQuery(schemas, exclude)
 
-		expectedQuery := "SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type FROM information_schema.tables AS t JOIN information_schema.columns AS c USING (table_schema, table_name) WHERE table_schema IN ($1) ORDER BY c.table_schema <> 'public', c.table_schema, t.table_type, c.table_name, c.column_name"
+		expectedQuery := "SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type, COALESCE((SELECT json_agg(e.enumlabel ORDER BY e.enumsortorder) FROM pg_catalog.pg_enum AS e JOIN pg_catalog.pg_type AS ty ON ty.oid = e.enumtypid JOIN pg_catalog.pg_namespace AS n ON n.oid = ty.typnamespace WHERE n.nspname = c.udt_schema AND ty.typname = c.udt_name), '[]') FROM information_schema.tables AS t JOIN information_schema.columns AS c USING (table_schema, table_name) WHERE table_schema IN ($1) ORDER BY c.table_schema <> 'public', c.table_schema, t.table_type, c.table_name, c.column_name"
 		expectedArgs := []interface{}{"public"}
 
 		is.Equal(query, expectedQuery)
@@ -30,7 +30,7 @@ func TestBuildTablesQuery(t *testing.T) {
 
 		query, args := buildTablesQuery(schemas, exclude)
 
-		expectedQuery := "SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type FROM information_schema.tables AS t JOIN information_schema.columns AS c USING (table_schema, table_name) WHERE table_schema IN ($1, $2) ORDER BY c.table_schema <> 'public', c.table_schema, t.table_type, c.table_name, c.column_name"
+		expectedQuery := "SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type, COALESCE((SELECT json_agg(e.enumlabel ORDER BY e.enumsortorder) FROM pg_catalog.pg_enum AS e JOIN pg_catalog.pg_type AS ty ON ty.oid = e.enumtypid JOIN pg_catalog.pg_namespace AS n ON n.oid = ty.typnamespace WHERE n.nspname = c.udt_schema AND ty.typname = c.udt_name), '[]') FROM information_schema.tables AS t JOIN information_schema.columns AS c USING (table_schema, table_name) WHERE table_schema IN ($1, $2) ORDER BY c.table_schema <> 'public', c.table_schema, t.table_type, c.table_name, c.column_name"
 		expectedArgs := []interface{}{"public", "geo"}
 
 		is.Equal(query, expectedQuery)
@@ -44,7 +44,7 @@ func TestBuildTablesQuery(t *testing.T) {
 
 		query, args := buildTablesQuery(schemas, exclude)
 
-		expectedQuery := "SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type FROM information_schema.tables AS t JOIN information_schema.columns AS c USING (table_schema, table_name) WHERE table_schema IN ($1, $2) AND table_name NOT IN ($3, $4) ORDER BY c.table_schema <> 'public', c.table_schema, t.table_type, c.table_name, c.column_name"
+		expectedQuery := "SELECT t.table_type, c.table_schema, c.table_name, c.column_name, c.data_type, COALESCE((SELECT json_agg(e.enumlabel ORDER BY e.enumsortorder) FROM pg_catalog.pg_enum AS e JOIN pg_catalog.pg_type AS ty ON ty.oid = e.enumtypid JOIN pg_catalog.pg_namespace AS n ON n.oid = ty.typnamespace WHERE n.nspname = c.udt_schema AND ty.typname = c.udt_name), '[]') FROM information_schema.tables AS t JOIN information_schema.columns AS c USING (table_schema, table_name) WHERE table_schema IN ($1, $2) AND table_name NOT IN ($3, $4) ORDER BY c.table_schema <> 'public', c.table_schema, t.table_type, c.table_name, c.column_name"
 
 		expectedArgs := []interface{}{"public", "geo", "schema_migrations", "meta"}
 
@@ -53,6 +53,61 @@ func TestBuildTablesQuery(t *testing.T) {
 	})
 }
 
+func TestExecuteTablesTenantTemplate(t *testing.T) {
+	is := is.New(t)
+
+	// rejected before the database is queried
+	_, err := executeTables(Config{Schemas: []string{"public"}, TenantTemplate: "tenant_template"})
+
+	is.True(err != nil)
+	is.Equal(err.Error(), "TenantTemplate and Schemas cannot be used together")
+}
+
+func TestBuildChildrenQuery(t *testing.T) {
+	is := is.New(t)
+
+	query, args := buildChildrenQuery([]string{"public", "geo"})
+
+	expectedQuery := "SELECT pn.nspname, pc.relname, cn.nspname, cc.relname FROM pg_catalog.pg_inherits AS i JOIN pg_catalog.pg_class AS pc ON pc.oid = i.inhparent JOIN pg_catalog.pg_namespace AS pn ON pn.oid = pc.relnamespace JOIN pg_catalog.pg_class AS cc ON cc.oid = i.inhrelid JOIN pg_catalog.pg_namespace AS cn ON cn.oid = cc.relnamespace WHERE pn.nspname IN ($1, $2) ORDER BY pn.nspname, pc.relname, cn.nspname, cc.relname"
+	expectedArgs := []interface{}{"public", "geo"}
+
+	is.Equal(query, expectedQuery)
+	is.Equal(args, expectedArgs)
+}
+
+func TestBuildViewsQuery(t *testing.T) {
+	is := is.New(t)
+
+	query, args := buildViewsQuery([]string{"public", "geo"})
+
+	expectedQuery := "SELECT table_schema, table_name, is_updatable, is_insertable_into, check_option FROM information_schema.views WHERE table_schema IN ($1, $2)"
+	expectedArgs := []interface{}{"public", "geo"}
+
+	is.Equal(query, expectedQuery)
+	is.Equal(args, expectedArgs)
+}
+
+func TestBuildMaterializedViewsQuery(t *testing.T) {
+	is := is.New(t)
+
+	query, args := buildMaterializedViewsQuery([]string{"public"}, []string{"user_stats"})
+
+	expectedQuery := "SELECT 'MATERIALIZED VIEW', n.nspname, c.relname, a.attname" +
+		", CASE WHEN ty.typcategory = 'A' THEN 'ARRAY' WHEN ty.typtype = 'e' THEN 'USER-DEFINED' ELSE format_type(a.atttypid, NULL) END" +
+		", COALESCE((SELECT json_agg(e.enumlabel ORDER BY e.enumsortorder)" +
+		" FROM pg_catalog.pg_enum AS e WHERE e.enumtypid = a.atttypid), '[]')" +
+		" FROM pg_catalog.pg_class AS c" +
+		" JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace" +
+		" JOIN pg_catalog.pg_attribute AS a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped" +
+		" JOIN pg_catalog.pg_type AS ty ON ty.oid = a.atttypid" +
+		" WHERE c.relkind = 'm' AND n.nspname IN ($1) AND c.relname NOT IN ($2)" +
+		" ORDER BY n.nspname <> 'public', n.nspname, c.relname, a.attname"
+	expectedArgs := []interface{}{"public", "user_stats"}
+
+	is.Equal(query, expectedQuery)
+	is.Equal(args, expectedArgs)
+}
+
 func TestTablePopulate(t *testing.T) {
 	type TT struct {
 		name        string
@@ -75,6 +130,23 @@ func TestTablePopulate(t *testing.T) {
 				Constructor: "USERS",
 			},
 		},
+		{
+			name: "materialized view",
+			table: Table{
+				Name:    "monthly_sales",
+				Schema:  "public",
+				RawType: "MATERIALIZED VIEW",
+			},
+			isDuplicate: false,
+			result: Table{
+				Name:        "monthly_sales",
+				Schema:      "public",
+				RawType:     "MATERIALIZED VIEW",
+				StructName:  "MVIEW_MONTHLY_SALES",
+				Constructor: "MONTHLY_SALES",
+				ReadOnly:    true,
+			},
+		},
 		{
 			name: "normal table name, is duplicate",
 			table: Table{
@@ -38,6 +38,9 @@ import (
 {{template "table_struct_definition" $table}}
 {{template "table_constructor" $table}}
 {{template "table_as" $table}}
+{{- if eq $table.RawType "MATERIALIZED VIEW"}}
+{{template "materialized_view_assert" $table}}
+{{- end}}
 {{- end}}
 
 {{- define "table_struct_definition"}}
@@ -46,6 +49,24 @@ import (
 // {{export $table.StructName}} references the {{$table.Schema}}.{{quoteSpace $table.Name}} table.
 {{- else if eq $table.RawType "VIEW"}}
 // {{export $table.StructName}} references the {{$table.Schema}}.{{quoteSpace $table.Name}} view.
+{{- if $table.ReadOnly}}
+//
+// The view is read-only.
+{{- else if and $table.CheckOption (ne $table.CheckOption "NONE")}}
+//
+// The view is updatable WITH {{$table.CheckOption}} CHECK OPTION, rows written
+// through it must remain visible in it.
+{{- end}}
+{{- else if eq $table.RawType "MATERIALIZED VIEW"}}
+// {{export $table.StructName}} references the {{$table.Schema}}.{{quoteSpace $table.Name}} materialized view.
+//
+// The materialized view is read-only, use sq.RefreshMaterializedView to
+// refresh it.
+{{- end}}
+{{- if $table.Children}}
+//
+// It has child tables, whose rows it also contains unless it is queried
+// with Only(): {{join $table.Children ", "}}.
 {{- end}}
 type {{export $table.StructName}} struct {
 	*sq.TableInfo
@@ -62,14 +83,32 @@ type {{export $table.StructName}} struct {
 // {{export $table.Constructor}} creates an instance of the {{$table.Schema}}.{{quoteSpace $table.Name}} table.
 {{- else if eq $table.RawType "VIEW"}}
 // {{export $table.Constructor}} creates an instance of the {{$table.Schema}}.{{quoteSpace $table.Name}} view.
+{{- else if eq $table.RawType "MATERIALIZED VIEW"}}
+// {{export $table.Constructor}} creates an instance of the {{$table.Schema}}.{{quoteSpace $table.Name}} materialized view.
 {{- end}}
+{{- if $table.Tenant}}
+//
+// The {{$table.Schema}} schema is a tenant template: the instance is rendered in
+// the schema that the resolver resolves the tenantID to. Queries on an
+// instance with a nil resolver or an empty tenantID fail.
+func {{export $table.Constructor}}(resolver sq.SchemaResolver, tenantID string) {{export $table.StructName}} {
+{{- else}}
 func {{export $table.Constructor}}() {{export $table.StructName}} {
+{{- end}}
 	tbl := {{export $table.StructName}}{TableInfo: &sq.TableInfo{
 		Schema: "{{$table.Schema}}",
 		Name: "{{$table.Name}}",
+		{{- if $table.ReadOnly}}
+		ReadOnly: true,
+		{{- end}}
+		{{- if $table.Tenant}}
+		SchemaResolver: resolver,
+		TenantID: tenantID,
+		Tenant: true,
+		{{- end}}
 	},}
 	{{- range $_, $field := $table.Fields}}
-	tbl.{{export $field.Name}} = {{$field.Constructor}}("{{$field.Name}}", tbl.TableInfo)
+	tbl.{{export $field.Name}} = {{$field.Constructor}}("{{$field.Name}}", tbl.TableInfo{{range $_, $value := $field.EnumValues}}, {{printf "%q" $value}}{{end}})
 	{{- end}}
 	return tbl
 }
@@ -82,12 +121,21 @@ func {{export $table.Constructor}}() {{export $table.StructName}} {
 // As modifies the alias of the underlying table.
 {{- else if eq $table.RawType "VIEW"}}
 // As modifies the alias of the underlying view.
+{{- else if eq $table.RawType "MATERIALIZED VIEW"}}
+// As modifies the alias of the underlying materialized view.
 {{- end}}
 func (tbl {{export $table.StructName}}) As(alias string) {{export $table.StructName}} {
 	tbl.TableInfo.Alias = alias
 	return tbl
 }
 {{- end}}
+{{- end}}
+
+{{- define "materialized_view_assert"}}
+{{- with $table := .}}
+// AssertMaterializedView implements the sq.MaterializedView interface.
+func (tbl {{export $table.StructName}}) AssertMaterializedView() {}
+{{- end}}
 {{- end}}`
 
 var functionsTemplate = `// Code generated by 'sqgen-postgres functions'; DO NOT EDIT.
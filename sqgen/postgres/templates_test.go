@@ -49,6 +49,13 @@ func TestTablesTemplate(t *testing.T) {
 						Type:        FieldTypeTime,
 						Constructor: FieldConstructorTime,
 					},
+					{
+						Name:        "status",
+						RawType:     "USER-DEFINED",
+						Type:        FieldTypeEnum,
+						Constructor: FieldConstructorEnum,
+						EnumValues:  []string{"active", "banned"},
+					},
 				},
 			},
 		},
@@ -72,6 +79,7 @@ type TABLE_USERS struct {
 	ID sq.NumberField
 	FIRST_NAME sq.StringField
 	DATE_CREATED sq.TimeField
+	STATUS sq.EnumField
 }
 
 // USERS creates an instance of the public.users table.
@@ -83,6 +91,7 @@ func USERS() TABLE_USERS {
 	tbl.ID = sq.NewNumberField("id", tbl.TableInfo)
 	tbl.FIRST_NAME = sq.NewStringField("first_name", tbl.TableInfo)
 	tbl.DATE_CREATED = sq.NewTimeField("date_created", tbl.TableInfo)
+	tbl.STATUS = sq.NewEnumField("status", tbl.TableInfo, "active", "banned")
 	return tbl
 }
 
@@ -99,6 +108,170 @@ func (tbl TABLE_USERS) As(alias string) TABLE_USERS {
 	is.NoErr(err)
 }
 
+func TestTablesTemplate_Children(t *testing.T) {
+	is := is.New(t)
+
+	template, err := getTablesTemplate()
+	is.NoErr(err)
+
+	var writer strings.Builder
+
+	data := TablesTemplateData{
+		PackageName: "tables",
+		Tables: []Table{
+			{
+				Name:        "events",
+				Schema:      "public",
+				StructName:  "TABLE_EVENTS",
+				RawType:     "BASE TABLE",
+				Constructor: "EVENTS",
+				Children:    []string{"public.events_2020", "public.events_2021"},
+			},
+		},
+	}
+
+	err = template.Execute(&writer, data)
+	is.NoErr(err)
+
+	expected := `// TABLE_EVENTS references the public.events table.
+//
+// It has child tables, whose rows it also contains unless it is queried
+// with Only(): public.events_2020, public.events_2021.
+type TABLE_EVENTS struct {`
+	is.True(strings.Contains(writer.String(), expected))
+}
+
+func TestTablesTemplate_Views(t *testing.T) {
+	is := is.New(t)
+
+	template, err := getTablesTemplate()
+	is.NoErr(err)
+
+	var writer strings.Builder
+
+	data := TablesTemplateData{
+		PackageName: "tables",
+		Tables: []Table{
+			{
+				Name:        "user_stats",
+				Schema:      "public",
+				StructName:  "VIEW_USER_STATS",
+				RawType:     "VIEW",
+				Constructor: "USER_STATS",
+				ReadOnly:    true,
+				CheckOption: "NONE",
+			},
+			{
+				Name:        "active_users",
+				Schema:      "public",
+				StructName:  "VIEW_ACTIVE_USERS",
+				RawType:     "VIEW",
+				Constructor: "ACTIVE_USERS",
+				CheckOption: "CASCADED",
+			},
+		},
+	}
+
+	err = template.Execute(&writer, data)
+	is.NoErr(err)
+
+	out := writer.String()
+	is.True(strings.Contains(out, `// VIEW_USER_STATS references the public.user_stats view.
+//
+// The view is read-only.
+type VIEW_USER_STATS struct {`))
+	is.True(strings.Contains(out, `tbl := VIEW_USER_STATS{TableInfo: &sq.TableInfo{
+		Schema: "public",
+		Name: "user_stats",
+		ReadOnly: true,
+	},}`))
+	is.True(strings.Contains(out, `// VIEW_ACTIVE_USERS references the public.active_users view.
+//
+// The view is updatable WITH CASCADED CHECK OPTION, rows written
+// through it must remain visible in it.
+type VIEW_ACTIVE_USERS struct {`))
+	is.True(strings.Contains(out, `tbl := VIEW_ACTIVE_USERS{TableInfo: &sq.TableInfo{
+		Schema: "public",
+		Name: "active_users",
+	},}`))
+}
+
+func TestTablesTemplate_MaterializedView(t *testing.T) {
+	is := is.New(t)
+
+	template, err := getTablesTemplate()
+	is.NoErr(err)
+
+	var writer strings.Builder
+
+	data := TablesTemplateData{
+		PackageName: "tables",
+		Tables: []Table{
+			{
+				Name:        "monthly_sales",
+				Schema:      "public",
+				StructName:  "MVIEW_MONTHLY_SALES",
+				RawType:     "MATERIALIZED VIEW",
+				Constructor: "MONTHLY_SALES",
+				ReadOnly:    true,
+			},
+		},
+	}
+
+	err = template.Execute(&writer, data)
+	is.NoErr(err)
+
+	out := writer.String()
+	is.True(strings.Contains(out, `// MVIEW_MONTHLY_SALES references the public.monthly_sales materialized view.
+//
+// The materialized view is read-only, use sq.RefreshMaterializedView to
+// refresh it.
+type MVIEW_MONTHLY_SALES struct {`))
+	is.True(strings.Contains(out, `ReadOnly: true,`))
+	is.True(strings.Contains(out, `// AssertMaterializedView implements the sq.MaterializedView interface.
+func (tbl MVIEW_MONTHLY_SALES) AssertMaterializedView() {}`))
+}
+
+func TestTablesTemplate_Tenant(t *testing.T) {
+	is := is.New(t)
+
+	template, err := getTablesTemplate()
+	is.NoErr(err)
+
+	var writer strings.Builder
+
+	data := TablesTemplateData{
+		PackageName: "tables",
+		Tables: []Table{
+			{
+				Name:        "users",
+				Schema:      "tenant_template",
+				StructName:  "TABLE_USERS",
+				RawType:     "BASE TABLE",
+				Constructor: "USERS",
+				Tenant:      true,
+			},
+		},
+	}
+
+	err = template.Execute(&writer, data)
+	is.NoErr(err)
+
+	is.True(strings.Contains(writer.String(), `// USERS creates an instance of the tenant_template.users table.
+//
+// The tenant_template schema is a tenant template: the instance is rendered in
+// the schema that the resolver resolves the tenantID to. Queries on an
+// instance with a nil resolver or an empty tenantID fail.
+func USERS(resolver sq.SchemaResolver, tenantID string) TABLE_USERS {
+	tbl := TABLE_USERS{TableInfo: &sq.TableInfo{
+		Schema: "tenant_template",
+		Name: "users",
+		SchemaResolver: resolver,
+		TenantID: tenantID,
+		Tenant: true,
+	},}`))
+}
+
 func TestFunctionsTemplate(t *testing.T) {
 	is := is.New(t)
 
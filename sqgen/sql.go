@@ -11,3 +11,14 @@ func SliceToSQL(args []string) string {
 
 	return "(?" + strings.Repeat(", ?", len(args)-1) + ")"
 }
+
+// ViewReadOnly reports whether a view is read-only from its is_updatable and
+// is_insertable_into columns in information_schema.views. Both dialects use
+// the same rule: a view is read-only only if it can neither be updated nor
+// inserted into. A view that supports just one of the two is not read-only,
+// and the statements it does not support fail in the database instead. MySQL
+// has no is_insertable_into column because a MySQL view is only insertable if
+// it is updatable, so its is_updatable is passed in for both.
+func ViewReadOnly(isUpdatable, isInsertableInto string) bool {
+	return isUpdatable == "NO" && isInsertableInto == "NO"
+}
@@ -42,3 +42,25 @@ func TestSliceToSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestViewReadOnly(t *testing.T) {
+	type TT struct {
+		name             string
+		isUpdatable      string
+		isInsertableInto string
+		result           bool
+	}
+	tests := []TT{
+		{name: "updatable and insertable", isUpdatable: "YES", isInsertableInto: "YES", result: false},
+		{name: "insertable only", isUpdatable: "NO", isInsertableInto: "YES", result: false},
+		{name: "updatable only", isUpdatable: "YES", isInsertableInto: "NO", result: false},
+		{name: "neither", isUpdatable: "NO", isInsertableInto: "NO", result: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(ViewReadOnly(tt.isUpdatable, tt.isInsertableInto), tt.result)
+		})
+	}
+}
@@ -29,4 +29,5 @@ func QuoteSpace(s string) string {
 var FuncMap template.FuncMap = map[string]interface{}{
 	"export":     Export,
 	"quoteSpace": QuoteSpace,
+	"join":       strings.Join,
 }
@@ -0,0 +1,96 @@
+// Package sqtest provides the transactional test scaffolding used by the sq
+// test suites, so that applications can give each of their tests an isolated
+// view of a real database.
+//
+// Every DB opened with OpenIsolatedDB runs all of its queries inside a single
+// transaction (courtesy of github.com/DATA-DOG/go-txdb) that is rolled back
+// when the DB is closed, leaving the database untouched for the next test.
+package sqtest
+
+import (
+	"database/sql"
+	"errors"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DATA-DOG/go-txdb"
+)
+
+// DriverName is the name of the database/sql driver registered by Register.
+const DriverName = "sqtest"
+
+var (
+	mu         sync.Mutex
+	registered bool
+	rnd        = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Register registers the transactional driver that OpenIsolatedDB uses. The
+// driver and dsn are the name of the underlying database/sql driver (e.g.
+// "postgres" or "mysql") and the data source name to connect to. Register
+// should be called once, usually from TestMain or an init function; calling it
+// again is a no-op.
+func Register(driver, dsn string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if registered {
+		return
+	}
+	txdb.Register(DriverName, driver, dsn)
+	registered = true
+}
+
+// OpenIsolatedDB opens a DB whose queries all run inside a transaction that is
+// rolled back when the DB is closed. A random suffix is appended to the name
+// so that every call gets its own transaction, even when called with the same
+// name (such as the name of a test that is run more than once).
+func OpenIsolatedDB(name string) (*sql.DB, error) {
+	mu.Lock()
+	ok := registered
+	mu.Unlock()
+	if !ok {
+		return nil, errors.New("sqtest: Register must be called before OpenIsolatedDB")
+	}
+	return sql.Open(DriverName, name+"_"+RandomString(8))
+}
+
+// Seed seeds the source used by RandomString. Seeding with the same value
+// makes the sequence of strings returned by RandomString deterministic, which
+// is useful for reproducing a failing test. The source is seeded with the
+// current time by default.
+func Seed(seed int64) {
+	mu.Lock()
+	defer mu.Unlock()
+	rnd = rand.New(rand.NewSource(seed))
+}
+
+// RandomString generates a random alphabetical string of length n. It is the
+// RandStringBytesMaskImprSrcSB function taken from
+// https://stackoverflow.com/a/31832326.
+func RandomString(n int) string {
+	const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	const (
+		letterIdxBits = 6                    // 6 bits to represent a letter index
+		letterIdxMask = 1<<letterIdxBits - 1 // All 1-bits, as many as letterIdxBits
+		letterIdxMax  = 63 / letterIdxBits   // # of letter indices fitting in 63 bits
+	)
+	mu.Lock()
+	defer mu.Unlock()
+	sb := strings.Builder{}
+	sb.Grow(n)
+	// A rnd.Int63() generates 63 random bits, enough for letterIdxMax characters!
+	for i, cache, remain := n-1, rnd.Int63(), letterIdxMax; i >= 0; {
+		if remain == 0 {
+			cache, remain = rnd.Int63(), letterIdxMax
+		}
+		if idx := int(cache & letterIdxMask); idx < len(letterBytes) {
+			sb.WriteByte(letterBytes[idx])
+			i--
+		}
+		cache >>= letterIdxBits
+		remain--
+	}
+	return sb.String()
+}
@@ -0,0 +1,29 @@
+package sqtest
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRandomString(t *testing.T) {
+	is := is.New(t)
+	Seed(42)
+	a, b := RandomString(8), RandomString(8)
+	is.Equal(8, len(a))
+	is.True(a != b)
+	Seed(42)
+	is.Equal(a, RandomString(8))
+	is.Equal(b, RandomString(8))
+}
+
+func TestOpenIsolatedDB(t *testing.T) {
+	is := is.New(t)
+	_, err := OpenIsolatedDB(t.Name())
+	is.True(err != nil) // Register has not been called
+	Register("postgres", "postgres://localhost/db")
+	Register("postgres", "postgres://localhost/db") // no-op, does not panic
+	db, err := OpenIsolatedDB(t.Name())
+	is.NoErr(err)
+	is.NoErr(db.Close())
+}